@@ -3,40 +3,58 @@ package main
 import (
 	"context"
 	"fmt"
-	"io"
 	"net/http"
 	"os"
 	"os/signal"
-	"strings"
 	"syscall"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/samber/do"
 	"github.com/sirupsen/logrus"
+
+	"github.com/olympuscloud/olympus-cloud-gcp/backend/go/internal/config"
+	"github.com/olympuscloud/olympus-cloud-gcp/backend/go/internal/container"
+	"github.com/olympuscloud/olympus-cloud-gcp/backend/go/internal/graphql"
+	"github.com/olympuscloud/olympus-cloud-gcp/backend/go/internal/proxy"
+	"github.com/olympuscloud/olympus-cloud-gcp/backend/go/internal/tracing"
+	"github.com/olympuscloud/olympus-cloud-gcp/backend/go/internal/ws"
 )
 
 func main() {
-	// Initialize logger
-	logrus.SetLevel(logrus.InfoLevel)
-	logrus.SetFormatter(&logrus.JSONFormatter{})
-
-	// Set Gin mode
-	gin.SetMode(gin.ReleaseMode)
+	injector := container.New()
+	defer func() {
+		if err := injector.Shutdown(); err != nil {
+			logrus.WithError(err).Error("Error during service shutdown")
+		}
+	}()
 
-	// Create Gin router
-	router := gin.New()
+	// Invoking Config first forces every later service to reuse the one
+	// parsed configuration rather than calling config.Load again.
+	cfg := do.MustInvoke[*config.Config](injector)
+	do.MustInvoke[*logrus.Logger](injector)
+	do.MustInvoke[*tracing.Provider](injector)
 
-	// Add middleware
-	router.Use(gin.Recovery())
-	router.Use(ginLogger())
+	engine := do.MustInvoke[*gin.Engine](injector)
 
 	// Health check endpoint
-	router.GET("/health", healthCheckHandler)
-	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
+	engine.GET("/health", healthCheckHandler(injector))
+	engine.GET("/metrics", gin.WrapH(promhttp.Handler()))
+
+	// GraphQL federation gateway over the Rust auth, Python analytics, and
+	// Rust commerce subgraphs
+	gateway := do.MustInvoke[*graphql.Gateway](injector)
+	engine.POST("/graphql", graphql.NewHandler(gateway))
+	engine.GET("/graphql/playground", graphql.NewPlaygroundHandler())
+
+	// WebSocket subscription hub: JWT-authenticated, Redis-backed fan-out per
+	// tenant, also speaking graphql-transport-ws for gqlgen subscriptions.
+	hub := do.MustInvoke[*ws.Hub](injector)
+	engine.GET("/ws", hub.Handler())
 
 	// API v1 routes
-	v1 := router.Group("/api/v1")
+	v1 := engine.Group("/api/v1")
 	{
 		v1.GET("/ping", func(c *gin.Context) {
 			c.JSON(http.StatusOK, gin.H{
@@ -46,23 +64,19 @@ func main() {
 			})
 		})
 
-		// Commerce service proxy routes
+		// Commerce service proxy routes: load-balanced across every healthy
+		// target in the discovered commerce pool, with retries on failure.
+		commercePool := do.MustInvoke[*proxy.Pool](injector)
+		commerceProxy := proxy.NewProxy(commercePool, 2, 100*time.Millisecond)
 		commerce := v1.Group("/commerce")
 		{
-			// Forward all commerce requests to Rust commerce service
-			commerce.Any("/*path", commerceProxyHandler)
+			commerce.Any("/*path", commerceProxy.Handler())
 		}
 	}
 
-	// Create HTTP server
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8080"
-	}
-
 	server := &http.Server{
-		Addr:         fmt.Sprintf(":%s", port),
-		Handler:      router,
+		Addr:         fmt.Sprintf(":%d", cfg.Port),
+		Handler:      engine,
 		ReadTimeout:  30 * time.Second,
 		WriteTimeout: 30 * time.Second,
 		IdleTimeout:  120 * time.Second,
@@ -70,7 +84,7 @@ func main() {
 
 	// Start server in goroutine
 	go func() {
-		logrus.WithField("port", port).Info("Starting Olympus API Gateway")
+		logrus.WithField("port", cfg.Port).Info("Starting Olympus API Gateway")
 		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 			logrus.WithError(err).Fatal("Failed to start server")
 		}
@@ -94,78 +108,36 @@ func main() {
 	logrus.Info("Server exited")
 }
 
-func healthCheckHandler(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{
-		"status":    "healthy",
-		"timestamp": time.Now().UTC(),
-		"service":   "olympus-api-gateway",
-		"version":   os.Getenv("VERSION"),
-	})
-}
-
-func ginLogger() gin.HandlerFunc {
-	return gin.LoggerWithFormatter(func(param gin.LogFormatterParams) string {
-		logrus.WithFields(logrus.Fields{
-			"timestamp":    param.TimeStamp.Format(time.RFC3339),
-			"status":       param.StatusCode,
-			"latency":      param.Latency,
-			"client_ip":    param.ClientIP,
-			"method":       param.Method,
-			"path":         param.Path,
-			"user_agent":   param.Request.UserAgent(),
-			"error":        param.ErrorMessage,
-		}).Info("HTTP Request")
-		return ""
-	})
-}
-
-func commerceProxyHandler(c *gin.Context) {
-	// Get the target Rust commerce service URL
-	commerceURL := os.Getenv("RUST_COMMERCE_SERVICE_URL")
-	if commerceURL == "" {
-		commerceURL = "http://localhost:3001" // Default port for Rust commerce service
-	}
-
-	// Build the target URL
-	targetPath := strings.TrimPrefix(c.Param("path"), "/")
-	targetURL := fmt.Sprintf("%s/%s", commerceURL, targetPath)
-	if c.Request.URL.RawQuery != "" {
-		targetURL += "?" + c.Request.URL.RawQuery
-	}
-
-	// Create the request
-	req, err := http.NewRequest(c.Request.Method, targetURL, c.Request.Body)
-	if err != nil {
-		logrus.WithError(err).Error("Failed to create proxy request")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
-		return
-	}
-
-	// Copy headers
-	for key, values := range c.Request.Header {
-		for _, value := range values {
-			req.Header.Add(key, value)
+// healthCheckHandler reports overall status plus the result of every
+// registered service's own HealthCheck, so a broken Redis connection or an
+// un-composed GraphQL schema shows up here instead of only failing later.
+func healthCheckHandler(injector *do.Injector) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		checks := injector.HealthCheck()
+
+		status := "healthy"
+		httpStatus := http.StatusOK
+		for _, err := range checks {
+			if err != nil {
+				status = "unhealthy"
+				httpStatus = http.StatusServiceUnavailable
+				break
+			}
 		}
-	}
 
-	// Make the request
-	client := &http.Client{Timeout: 30 * time.Second}
-	resp, err := client.Do(req)
-	if err != nil {
-		logrus.WithError(err).Error("Failed to make proxy request")
-		c.JSON(http.StatusBadGateway, gin.H{"error": "Service unavailable"})
-		return
-	}
-	defer resp.Body.Close()
-
-	// Copy response headers
-	for key, values := range resp.Header {
-		for _, value := range values {
-			c.Header(key, value)
+		errors := make(map[string]string, len(checks))
+		for name, err := range checks {
+			if err != nil {
+				errors[name] = err.Error()
+			}
 		}
-	}
 
-	// Copy response status and body
-	c.Status(resp.StatusCode)
-	io.Copy(c.Writer, resp.Body)
-}
\ No newline at end of file
+		c.JSON(httpStatus, gin.H{
+			"status":    status,
+			"timestamp": time.Now().UTC(),
+			"service":   "olympus-api-gateway",
+			"version":   os.Getenv("VERSION"),
+			"checks":    errors,
+		})
+	}
+}
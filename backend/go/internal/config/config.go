@@ -1,51 +1,107 @@
+// Package config loads the gateway's configuration from the environment
+// (via viper) and validates it with tag-driven rules that get stricter in
+// production. Values can reference a secret:// URI (see secrets.go) to be
+// resolved through a pluggable SecretProvider instead of being stored in
+// plaintext env vars. A Store (see store.go) additionally supports
+// reloading the Config at runtime when it's backed by a watched file.
 package config
 
 import (
+	"fmt"
 	"os"
 	"strconv"
 
+	"github.com/go-playground/validator/v10"
 	"github.com/joho/godotenv"
+	"github.com/samber/do"
 	"github.com/spf13/viper"
 )
 
 type Config struct {
 	// Server settings
 	Port        int    `mapstructure:"PORT"`
-	Environment string `mapstructure:"ENVIRONMENT"`
+	Environment string `mapstructure:"ENVIRONMENT" validate:"oneof=development staging production"`
 	LogLevel    string `mapstructure:"LOG_LEVEL"`
 
 	// Database settings
-	DatabaseURL      string `mapstructure:"DATABASE_URL"`
+	DatabaseURL      string `mapstructure:"DATABASE_URL" validate:"omitempty,url"`
 	DatabaseHost     string `mapstructure:"DATABASE_HOST"`
 	DatabasePort     int    `mapstructure:"DATABASE_PORT"`
 	DatabaseUser     string `mapstructure:"DATABASE_USER"`
-	DatabasePassword string `mapstructure:"DATABASE_PASSWORD"`
+	DatabasePassword string `mapstructure:"DATABASE_PASSWORD" validate:"required_if=Environment production"`
 	DatabaseName     string `mapstructure:"DATABASE_NAME"`
 
 	// Redis settings
-	RedisURL      string `mapstructure:"REDIS_URL"`
+	RedisURL      string `mapstructure:"REDIS_URL" validate:"omitempty,url"`
 	RedisHost     string `mapstructure:"REDIS_HOST"`
 	RedisPort     int    `mapstructure:"REDIS_PORT"`
 	RedisPassword string `mapstructure:"REDIS_PASSWORD"`
 	RedisDB       int    `mapstructure:"REDIS_DB"`
 
-	// JWT settings
-	JWTSecret           string `mapstructure:"JWT_SECRET"`
-	JWTExpirationHours  int    `mapstructure:"JWT_EXPIRATION_HOURS"`
-	JWTRefreshDays      int    `mapstructure:"JWT_REFRESH_DAYS"`
+	// JWT settings. Validate additionally rejects the dev default and
+	// requires RedisPassword in production, invariants the tag language
+	// below can't express on its own.
+	JWTSecret          string `mapstructure:"JWT_SECRET" validate:"min=16"`
+	JWTExpirationHours int    `mapstructure:"JWT_EXPIRATION_HOURS" validate:"min=1"`
+	JWTRefreshDays     int    `mapstructure:"JWT_REFRESH_DAYS" validate:"min=1"`
 
 	// External service URLs
-	RustAuthServiceURL    string `mapstructure:"RUST_AUTH_SERVICE_URL"`
-	PythonAnalyticsURL    string `mapstructure:"PYTHON_ANALYTICS_URL"`
+	RustAuthServiceURL string `mapstructure:"RUST_AUTH_SERVICE_URL" validate:"required,url"`
+	PythonAnalyticsURL string `mapstructure:"PYTHON_ANALYTICS_URL" validate:"required,url"`
+	CommerceServiceURL string `mapstructure:"RUST_COMMERCE_SERVICE_URL" validate:"required,url"`
+
+	// CommerceServiceURLs is the load-balanced pool behind the REST proxy,
+	// as opposed to CommerceServiceURL above which is the single canonical
+	// endpoint the GraphQL gateway composes as a subgraph.
+	CommerceServiceURLs []string `mapstructure:"RUST_COMMERCE_SERVICE_URLS" validate:"required,min=1,dive,url"`
+
+	// GraphQL federation gateway settings
+	GraphQLSchemaRefreshSeconds int `mapstructure:"GRAPHQL_SCHEMA_REFRESH_SECONDS" validate:"min=1"`
+
+	// OpenTelemetry tracing settings
+	OTelServiceName          string  `mapstructure:"OTEL_SERVICE_NAME" validate:"required"`
+	OTelExporterOTLPEndpoint string  `mapstructure:"OTEL_EXPORTER_OTLP_ENDPOINT"`
+	OTelExporterInsecure     bool    `mapstructure:"OTEL_EXPORTER_OTLP_INSECURE"`
+	OTelSamplerRatio         float64 `mapstructure:"OTEL_SAMPLER_RATIO" validate:"min=0,max=1"`
 
 	// Rate limiting
-	RateLimitRequests int `mapstructure:"RATE_LIMIT_REQUESTS"`
-	RateLimitWindow   int `mapstructure:"RATE_LIMIT_WINDOW"`
+	RateLimitRequests int `mapstructure:"RATE_LIMIT_REQUESTS" validate:"min=1"`
+	RateLimitWindow   int `mapstructure:"RATE_LIMIT_WINDOW" validate:"min=1"`
 
 	// CORS settings
-	CorsAllowedOrigins []string `mapstructure:"CORS_ALLOWED_ORIGINS"`
-	CorsAllowedMethods []string `mapstructure:"CORS_ALLOWED_METHODS"`
-	CorsAllowedHeaders []string `mapstructure:"CORS_ALLOWED_HEADERS"`
+	CorsAllowedOrigins []string `mapstructure:"CORS_ALLOWED_ORIGINS" validate:"required,min=1"`
+	CorsAllowedMethods []string `mapstructure:"CORS_ALLOWED_METHODS" validate:"required,min=1"`
+	CorsAllowedHeaders []string `mapstructure:"CORS_ALLOWED_HEADERS" validate:"required,min=1"`
+}
+
+// devJWTSecret is the placeholder JWTSecret falls back to outside
+// production. It satisfies the min=16 tag, so Validate checks for it
+// explicitly rather than relying on tags alone.
+const devJWTSecret = "dev-secret-key-change-in-production"
+
+var validate = validator.New()
+
+// Validate applies the struct tags above plus the invariants the tag
+// language can't express: in production, JWTSecret must not still be the
+// dev placeholder and RedisPassword must be set, since the default config
+// otherwise talks to an unauthenticated local Redis. Load always calls
+// this; Store.reload calls it again on every hot-reload before swapping in
+// the new Config.
+func (c *Config) Validate() error {
+	if err := validate.Struct(c); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+
+	if c.Environment == "production" {
+		if c.JWTSecret == devJWTSecret {
+			return fmt.Errorf("invalid config: JWT_SECRET must be set to a real secret in production")
+		}
+		if c.RedisPassword == "" {
+			return fmt.Errorf("invalid config: REDIS_PASSWORD is required in production")
+		}
+	}
+
+	return nil
 }
 
 func Load() (*Config, error) {
@@ -66,6 +122,13 @@ func Load() (*Config, error) {
 	viper.SetDefault("JWT_REFRESH_DAYS", 30)
 	viper.SetDefault("RUST_AUTH_SERVICE_URL", "http://localhost:8000")
 	viper.SetDefault("PYTHON_ANALYTICS_URL", "http://localhost:8001")
+	viper.SetDefault("RUST_COMMERCE_SERVICE_URL", "http://localhost:3001")
+	viper.SetDefault("RUST_COMMERCE_SERVICE_URLS", []string{"http://localhost:3001"})
+	viper.SetDefault("GRAPHQL_SCHEMA_REFRESH_SECONDS", 60)
+	viper.SetDefault("OTEL_SERVICE_NAME", "olympus-api-gateway")
+	viper.SetDefault("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	viper.SetDefault("OTEL_EXPORTER_OTLP_INSECURE", true)
+	viper.SetDefault("OTEL_SAMPLER_RATIO", 1.0)
 	viper.SetDefault("RATE_LIMIT_REQUESTS", 100)
 	viper.SetDefault("RATE_LIMIT_WINDOW", 60)
 	viper.SetDefault("CORS_ALLOWED_ORIGINS", []string{"*"})
@@ -87,10 +150,34 @@ func Load() (*Config, error) {
 		}
 	}
 
-	// Validate required configuration
+	// Default JWTSecret outside of production only; Validate rejects this
+	// placeholder once ENVIRONMENT=production instead of silently running
+	// with it.
 	if config.JWTSecret == "" {
-		config.JWTSecret = "dev-secret-key-change-in-production"
+		config.JWTSecret = devJWTSecret
+	}
+
+	if err := resolveSecrets(&config); err != nil {
+		return nil, fmt.Errorf("resolve secrets: %w", err)
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
 	}
 
 	return &config, nil
-}
\ No newline at end of file
+}
+
+// NewService adapts *Store to the do.Provider[*Config] shape so it can be
+// registered with container.New. It reads through Store.Current rather
+// than calling Load itself, so there's exactly one parse of the
+// environment/config file at startup -- both *Config and *Store resolve to
+// values built from that same parse.
+//
+// The value resolved here is a point-in-time snapshot. Services that want
+// to observe config changes without a restart should depend on *Store
+// (store.go) instead and read through Store.Current.
+func NewService(i *do.Injector) (*Config, error) {
+	store := do.MustInvoke[*Store](i)
+	return store.Current(), nil
+}
@@ -0,0 +1,90 @@
+package config
+
+import "testing"
+
+// validConfig returns a Config that satisfies every struct tag on its own,
+// so each test case only needs to break the one invariant it's checking.
+func validConfig() *Config {
+	return &Config{
+		Environment:                 "development",
+		DatabasePassword:            "",
+		JWTSecret:                   devJWTSecret,
+		JWTExpirationHours:          24,
+		JWTRefreshDays:              30,
+		RustAuthServiceURL:          "http://localhost:8000",
+		PythonAnalyticsURL:          "http://localhost:8001",
+		CommerceServiceURL:          "http://localhost:3001",
+		CommerceServiceURLs:         []string{"http://localhost:3001"},
+		GraphQLSchemaRefreshSeconds: 60,
+		OTelServiceName:             "olympus-api-gateway",
+		OTelSamplerRatio:            1.0,
+		RateLimitRequests:           100,
+		RateLimitWindow:             60,
+		CorsAllowedOrigins:          []string{"*"},
+		CorsAllowedMethods:          []string{"GET"},
+		CorsAllowedHeaders:          []string{"*"},
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	t.Run("a valid development config passes", func(t *testing.T) {
+		if err := validConfig().Validate(); err != nil {
+			t.Fatalf("Validate() = %v, want nil", err)
+		}
+	})
+
+	t.Run("a missing required field fails regardless of environment", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.RustAuthServiceURL = ""
+		if err := cfg.Validate(); err == nil {
+			t.Fatal("Validate() = nil, want error for missing RustAuthServiceURL")
+		}
+	})
+
+	t.Run("the dev JWT secret is rejected in production", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.Environment = "production"
+		cfg.RedisPassword = "a-real-redis-password"
+		cfg.DatabasePassword = "a-real-db-password"
+		if err := cfg.Validate(); err == nil {
+			t.Fatal("Validate() = nil, want error for the dev JWT secret in production")
+		}
+	})
+
+	t.Run("a real JWT secret is accepted in production", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.Environment = "production"
+		cfg.JWTSecret = "a-real-secret-thats-long-enough"
+		cfg.RedisPassword = "a-real-redis-password"
+		cfg.DatabasePassword = "a-real-db-password"
+		if err := cfg.Validate(); err != nil {
+			t.Fatalf("Validate() = %v, want nil", err)
+		}
+	})
+
+	t.Run("a missing Redis password is rejected in production", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.Environment = "production"
+		cfg.JWTSecret = "a-real-secret-thats-long-enough"
+		cfg.DatabasePassword = "a-real-db-password"
+		cfg.RedisPassword = ""
+		if err := cfg.Validate(); err == nil {
+			t.Fatal("Validate() = nil, want error for missing RedisPassword in production")
+		}
+	})
+
+	t.Run("a missing database password is rejected in production but not development", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.DatabasePassword = ""
+		if err := cfg.Validate(); err != nil {
+			t.Fatalf("Validate() = %v, want nil outside production", err)
+		}
+
+		cfg.Environment = "production"
+		cfg.JWTSecret = "a-real-secret-thats-long-enough"
+		cfg.RedisPassword = "a-real-redis-password"
+		if err := cfg.Validate(); err == nil {
+			t.Fatal("Validate() = nil, want error for missing DatabasePassword in production")
+		}
+	})
+}
@@ -0,0 +1,96 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/samber/do"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+// Store holds the gateway's current Config behind an atomic pointer so
+// readers never block on a reload, and lets services subscribe to be
+// notified when a reload replaces it.
+//
+// Reload is driven by viper.WatchConfig, which only fires for a configured
+// config file. Env-var-only deployments -- the default, since Load never
+// calls viper.SetConfigFile -- set CONFIG_FILE to opt in; without it,
+// NewStore behaves like a one-shot Load and Current never changes.
+type Store struct {
+	ptr atomic.Pointer[Config]
+
+	mu          sync.Mutex
+	subscribers []func(*Config)
+}
+
+// NewStore loads the initial Config and, if CONFIG_FILE names a file,
+// starts watching it for changes.
+func NewStore() (*Store, error) {
+	cfg, err := Load()
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Store{}
+	s.ptr.Store(cfg)
+
+	if path := os.Getenv("CONFIG_FILE"); path != "" {
+		viper.SetConfigFile(path)
+		if err := viper.ReadInConfig(); err != nil {
+			return nil, fmt.Errorf("read config file %q: %w", path, err)
+		}
+		viper.OnConfigChange(func(fsnotify.Event) {
+			s.reload()
+		})
+		viper.WatchConfig()
+	}
+
+	return s, nil
+}
+
+// reload re-runs Load and, if it produces a valid Config, swaps it in and
+// notifies subscribers. A reload that fails to parse or validate is
+// logged and discarded -- the Store keeps serving the last good Config
+// rather than take down the gateway over a bad edit to the config file.
+func (s *Store) reload() {
+	cfg, err := Load()
+	if err != nil {
+		logrus.WithError(err).Warn("config: reload failed, keeping previous config")
+		return
+	}
+
+	s.ptr.Store(cfg)
+
+	s.mu.Lock()
+	subscribers := append([]func(*Config){}, s.subscribers...)
+	s.mu.Unlock()
+
+	for _, subscriber := range subscribers {
+		subscriber(cfg)
+	}
+}
+
+// Current returns the most recently loaded Config. Safe for concurrent use.
+func (s *Store) Current() *Config {
+	return s.ptr.Load()
+}
+
+// Subscribe registers fn to be called with the new Config every time a
+// reload replaces it. fn is not invoked with the Store's initial Config --
+// callers should call Current once up front for that.
+func (s *Store) Subscribe(fn func(*Config)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.subscribers = append(s.subscribers, fn)
+}
+
+// NewStoreService adapts NewStore to the do.Provider[*Store] shape so it
+// can be registered with container.New alongside the plain *Config
+// snapshot that NewService provides.
+func NewStoreService(_ *do.Injector) (*Store, error) {
+	return NewStore()
+}
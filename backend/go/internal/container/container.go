@@ -0,0 +1,40 @@
+// Package container wires up the dependency-injection graph for the API
+// gateway: every long-lived service (config, logging, Redis, the GraphQL
+// gateway, the Gin router) is registered once here and resolved by type
+// through a do.Injector instead of being constructed ad hoc in main.
+package container
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/samber/do"
+
+	"github.com/olympuscloud/olympus-cloud-gcp/backend/go/internal/config"
+	"github.com/olympuscloud/olympus-cloud-gcp/backend/go/internal/graphql"
+	"github.com/olympuscloud/olympus-cloud-gcp/backend/go/internal/logging"
+	"github.com/olympuscloud/olympus-cloud-gcp/backend/go/internal/proxy"
+	"github.com/olympuscloud/olympus-cloud-gcp/backend/go/internal/redisclient"
+	"github.com/olympuscloud/olympus-cloud-gcp/backend/go/internal/router"
+	"github.com/olympuscloud/olympus-cloud-gcp/backend/go/internal/tracing"
+	"github.com/olympuscloud/olympus-cloud-gcp/backend/go/internal/ws"
+)
+
+// New registers every backend service with a fresh injector. Services are
+// lazy: nothing is constructed until something invokes it, so registration
+// order here doesn't need to match startup order -- do resolves each
+// service's own dependencies (also via the injector) the first time it is
+// built.
+func New() *do.Injector {
+	injector := do.New()
+
+	do.Provide(injector, config.NewService)
+	do.Provide(injector, config.NewStoreService)
+	do.Provide(injector, logging.NewService)
+	do.Provide(injector, tracing.NewService)
+	do.Provide(injector, redisclient.NewService)
+	do.Provide(injector, graphql.NewService)
+	do.Provide(injector, proxy.NewCommerceService)
+	do.Provide(injector, ws.NewService)
+	do.Provide[*gin.Engine](injector, router.NewService)
+
+	return injector
+}
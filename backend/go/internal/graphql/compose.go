@@ -0,0 +1,127 @@
+package graphql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+	"github.com/vektah/gqlparser/v2/validator"
+)
+
+// rootTypeNames are the operation root types a subgraph is allowed to
+// contribute fields to via `extend type ... { ... }`.
+var rootTypeNames = map[string]bool{
+	"Query":        true,
+	"Mutation":     true,
+	"Subscription": true,
+}
+
+// federationDirectives declares the subset of the Apollo Federation
+// directive vocabulary this gateway understands. Subgraph SDL is composed
+// against gqlparser's built-in prelude, which doesn't know about `@key`,
+// so it has to be merged in alongside it.
+var federationDirectives = &ast.Source{
+	Name:    "federation",
+	BuiltIn: true,
+	Input: `
+		directive @key(fields: String!) on OBJECT | INTERFACE
+	`,
+}
+
+// supergraph is the result of composing every subgraph's SDL: a single
+// merged schema plus enough bookkeeping to plan a query across subgraphs.
+type supergraph struct {
+	schema *ast.Schema
+
+	// fieldOwner maps "TypeName.fieldName" to the subgraph that resolves it.
+	fieldOwner map[string]string
+
+	// entityOwner maps an entity type name (one carrying @key) to the
+	// subgraph that owns its canonical representation.
+	entityOwner map[string]string
+
+	// entityKeyFields maps an entity type name to the field names listed in
+	// its @key(fields: "...") directive, used to build _entities
+	// representations.
+	entityKeyFields map[string][]string
+}
+
+// composeSchemas fetches each subgraph's SDL and merges them into one
+// federated schema, recording which subgraph owns each root field and
+// each @key'd entity type so queries can be planned and _entities lookups
+// routed correctly.
+func composeSchemas(subgraphs []*subgraphSDL) (*supergraph, error) {
+	doc := &ast.SchemaDocument{}
+	fieldOwner := map[string]string{}
+	entityOwner := map[string]string{}
+	entityKeyFields := map[string][]string{}
+
+	for _, sg := range subgraphs {
+		sd, err := parser.ParseSchema(&ast.Source{Name: sg.name, Input: sg.sdl})
+		if err != nil {
+			return nil, fmt.Errorf("parse sdl for subgraph %s: %w", sg.name, err)
+		}
+
+		for _, def := range sd.Definitions {
+			if def.Kind == ast.Object {
+				if keyFields := keyDirectiveFields(def); keyFields != nil {
+					entityOwner[def.Name] = sg.name
+					entityKeyFields[def.Name] = keyFields
+				}
+			}
+		}
+		for _, ext := range sd.Extensions {
+			for _, field := range ext.Fields {
+				fieldOwner[ext.Name+"."+field.Name] = sg.name
+			}
+			if keyFields := keyDirectiveFields(ext); keyFields != nil && entityOwner[ext.Name] == "" {
+				entityOwner[ext.Name] = sg.name
+				entityKeyFields[ext.Name] = keyFields
+			}
+		}
+
+		doc.Merge(sd)
+	}
+
+	builtins, err := parser.ParseSchemas(validator.Prelude, federationDirectives)
+	if err != nil {
+		return nil, fmt.Errorf("parse gqlparser prelude: %w", err)
+	}
+	doc.Merge(builtins)
+
+	schema, err := validator.ValidateSchemaDocument(doc)
+	if err != nil {
+		return nil, fmt.Errorf("validate composed schema: %w", err)
+	}
+
+	return &supergraph{
+		schema:          schema,
+		fieldOwner:      fieldOwner,
+		entityOwner:     entityOwner,
+		entityKeyFields: entityKeyFields,
+	}, nil
+}
+
+// keyDirectiveFields returns the field names declared by a type's
+// @key(fields: "a b") directive, or nil if it carries none. Only a single,
+// flat field set is supported; compound/nested keys are out of scope.
+func keyDirectiveFields(def *ast.Definition) []string {
+	for _, d := range def.Directives {
+		if d.Name != "key" {
+			continue
+		}
+		if arg := d.Arguments.ForName("fields"); arg != nil && arg.Value != nil {
+			return strings.Fields(strings.Trim(arg.Value.Raw, "\""))
+		}
+	}
+	return nil
+}
+
+// subgraphSDL pairs a subgraph name with the SDL fetched from it, kept
+// separate from Subgraph so composition has no dependency on the HTTP
+// client used to reach it.
+type subgraphSDL struct {
+	name string
+	sdl  string
+}
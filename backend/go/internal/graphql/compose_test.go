@@ -0,0 +1,103 @@
+package graphql
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestComposeSchemas(t *testing.T) {
+	tests := []struct {
+		name          string
+		subgraphs     []*subgraphSDL
+		wantFieldOwn  map[string]string
+		wantEntOwner  map[string]string
+		wantEntKeys   map[string][]string
+		wantErrSubstr string
+	}{
+		{
+			name: "single subgraph with an entity",
+			subgraphs: []*subgraphSDL{
+				{name: "commerce", sdl: `
+					type Query { products: [Product!]! }
+					type Product @key(fields: "id") { id: String! name: String! }
+				`},
+			},
+			wantFieldOwn: map[string]string{},
+			wantEntOwner: map[string]string{"Product": "commerce"},
+			wantEntKeys:  map[string][]string{"Product": {"id"}},
+		},
+		{
+			name: "second subgraph extends the first's entity",
+			subgraphs: []*subgraphSDL{
+				{name: "commerce", sdl: `
+					type Query { products: [Product!]! }
+					type Product @key(fields: "id") { id: String! name: String! }
+				`},
+				{name: "analytics", sdl: `
+					extend type Product @key(fields: "id") { viewCount: Int! }
+				`},
+			},
+			wantFieldOwn: map[string]string{"Product.viewCount": "analytics"},
+			wantEntOwner: map[string]string{"Product": "commerce"},
+			wantEntKeys:  map[string][]string{"Product": {"id"}},
+		},
+		{
+			name: "root field extension is attributed to its subgraph",
+			subgraphs: []*subgraphSDL{
+				{name: "auth", sdl: `type Query { me: String }`},
+				{name: "commerce", sdl: `
+					extend type Query { orders: [String!]! }
+				`},
+			},
+			wantFieldOwn: map[string]string{"Query.orders": "commerce"},
+			wantEntOwner: map[string]string{},
+			wantEntKeys:  map[string][]string{},
+		},
+		{
+			name: "invalid sdl fails to parse",
+			subgraphs: []*subgraphSDL{
+				{name: "broken", sdl: `type Query { `},
+			},
+			wantErrSubstr: "parse sdl for subgraph broken",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg, err := composeSchemas(tt.subgraphs)
+			if tt.wantErrSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErrSubstr) {
+					t.Fatalf("composeSchemas() error = %v, want substring %q", err, tt.wantErrSubstr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("composeSchemas() unexpected error: %v", err)
+			}
+
+			for k, want := range tt.wantFieldOwn {
+				if got := sg.fieldOwner[k]; got != want {
+					t.Errorf("fieldOwner[%q] = %q, want %q", k, got, want)
+				}
+			}
+			for k, want := range tt.wantEntOwner {
+				if got := sg.entityOwner[k]; got != want {
+					t.Errorf("entityOwner[%q] = %q, want %q", k, got, want)
+				}
+			}
+			for k, want := range tt.wantEntKeys {
+				got := sg.entityKeyFields[k]
+				if len(got) != len(want) {
+					t.Errorf("entityKeyFields[%q] = %v, want %v", k, got, want)
+					continue
+				}
+				for i := range want {
+					if got[i] != want[i] {
+						t.Errorf("entityKeyFields[%q] = %v, want %v", k, got, want)
+						break
+					}
+				}
+			}
+		})
+	}
+}
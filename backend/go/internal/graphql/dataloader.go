@@ -0,0 +1,122 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// entityRequest is one pending "resolve this representation" call.
+type entityRequest struct {
+	representation map[string]interface{}
+	result         chan entityResult
+}
+
+type entityResult struct {
+	data json.RawMessage
+	err  error
+}
+
+// EntityLoader batches `_entities` lookups against a single subgraph within
+// a short time window, so a query selecting the same field across many
+// entities issues one subrequest instead of one per entity.
+type EntityLoader struct {
+	subgraph *Subgraph
+	query    string
+	headers  http.Header
+	wait     time.Duration
+	maxBatch int
+
+	mu      sync.Mutex
+	pending []*entityRequest
+	timer   *time.Timer
+}
+
+// NewEntityLoader returns a loader that batches calls made within `wait` of
+// each other, up to maxBatch representations per subgraph request. query is
+// the `_entities` document to send, selecting whichever extension fields
+// the caller needs for this entity type. headers is forwarded on every
+// batched subrequest so the owning subgraph sees the same caller identity
+// as the root query.
+func NewEntityLoader(subgraph *Subgraph, query string, headers http.Header, wait time.Duration, maxBatch int) *EntityLoader {
+	return &EntityLoader{subgraph: subgraph, query: query, headers: headers, wait: wait, maxBatch: maxBatch}
+}
+
+// Load queues a representation for batched resolution and blocks until the
+// batch it was folded into has been resolved.
+func (l *EntityLoader) Load(ctx context.Context, representation map[string]interface{}) (json.RawMessage, error) {
+	req := &entityRequest{representation: representation, result: make(chan entityResult, 1)}
+
+	l.mu.Lock()
+	l.pending = append(l.pending, req)
+	if len(l.pending) >= l.maxBatch {
+		batch := l.pending
+		l.pending = nil
+		if l.timer != nil {
+			l.timer.Stop()
+			l.timer = nil
+		}
+		go l.dispatch(ctx, batch)
+	} else if l.timer == nil {
+		l.timer = time.AfterFunc(l.wait, func() {
+			l.mu.Lock()
+			batch := l.pending
+			l.pending = nil
+			l.timer = nil
+			l.mu.Unlock()
+			l.dispatch(ctx, batch)
+		})
+	}
+	l.mu.Unlock()
+
+	select {
+	case res := <-req.result:
+		return res.data, res.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (l *EntityLoader) dispatch(ctx context.Context, batch []*entityRequest) {
+	if len(batch) == 0 {
+		return
+	}
+
+	representations := make([]interface{}, len(batch))
+	for i, req := range batch {
+		representations[i] = req.representation
+	}
+
+	resp, err := l.subgraph.execute(ctx, graphqlRequest{
+		Query:     l.query,
+		Variables: map[string]interface{}{"representations": representations},
+	}, l.headers)
+	if err != nil {
+		err = fmt.Errorf("subgraph %s: resolve entities: %w", l.subgraph.Name, err)
+		for _, req := range batch {
+			req.result <- entityResult{err: err}
+		}
+		return
+	}
+
+	var payload struct {
+		Entities []json.RawMessage `json:"_entities"`
+	}
+	if decodeErr := json.Unmarshal(resp.Data, &payload); decodeErr != nil {
+		for _, req := range batch {
+			req.result <- entityResult{err: fmt.Errorf("decode entities response: %w", decodeErr)}
+		}
+		return
+	}
+
+	for i, req := range batch {
+		if i >= len(payload.Entities) {
+			req.result <- entityResult{err: fmt.Errorf("subgraph %s: missing entity in batch response", l.subgraph.Name)}
+			continue
+		}
+		req.result <- entityResult{data: payload.Entities[i]}
+	}
+}
@@ -0,0 +1,164 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/samber/do"
+	"github.com/sirupsen/logrus"
+
+	"github.com/olympuscloud/olympus-cloud-gcp/backend/go/internal/config"
+)
+
+// Gateway owns the composed federated schema and the subgraphs it was built
+// from. The schema is refreshed on a timer so subgraph deploys don't require
+// restarting the gateway. Both the subgraph list and the refresh interval
+// are held behind atomics so a config hot-reload can swap them in without
+// restarting the gateway either. It implements do.Healthcheckable and
+// do.Shutdownable so the DI container can supervise it like any other
+// service.
+type Gateway struct {
+	subgraphs       atomic.Pointer[[]*Subgraph]
+	refreshInterval atomic.Int64 // nanoseconds, time.Duration
+
+	current atomic.Pointer[supergraph]
+	cancel  context.CancelFunc
+}
+
+// NewGateway builds a Gateway for the given subgraphs. Call Start before
+// serving any /graphql traffic so a composed schema is available.
+func NewGateway(subgraphs []*Subgraph, refreshInterval time.Duration) *Gateway {
+	g := &Gateway{}
+	g.subgraphs.Store(&subgraphs)
+	g.refreshInterval.Store(int64(refreshInterval))
+	return g
+}
+
+// NewService builds a Gateway from the injected config Store and starts
+// it, fitting the do.Provider[*Gateway] shape so it can be registered with
+// container.New. It subscribes to the Store so a hot-reloaded change to
+// the subgraph URLs or GraphQLSchemaRefreshSeconds takes effect without a
+// restart: the new subgraph list and interval are picked up on the
+// refresh loop's next tick.
+func NewService(i *do.Injector) (*Gateway, error) {
+	store := do.MustInvoke[*config.Store](i)
+
+	gw := NewGateway(subgraphsFromConfig(store.Current()), refreshIntervalFromConfig(store.Current()))
+	store.Subscribe(func(cfg *config.Config) {
+		subgraphs := subgraphsFromConfig(cfg)
+		gw.subgraphs.Store(&subgraphs)
+		gw.refreshInterval.Store(int64(refreshIntervalFromConfig(cfg)))
+	})
+
+	if err := gw.Start(); err != nil {
+		return nil, err
+	}
+	return gw, nil
+}
+
+func subgraphsFromConfig(cfg *config.Config) []*Subgraph {
+	return []*Subgraph{
+		NewSubgraph("auth", cfg.RustAuthServiceURL),
+		NewSubgraph("analytics", cfg.PythonAnalyticsURL),
+		NewSubgraph("commerce", cfg.CommerceServiceURL),
+	}
+}
+
+func refreshIntervalFromConfig(cfg *config.Config) time.Duration {
+	return time.Duration(cfg.GraphQLSchemaRefreshSeconds) * time.Second
+}
+
+// Start performs the first schema composition and then refreshes it on
+// refreshInterval until Shutdown is called, re-reading both the subgraph
+// list and the interval on every tick so a hot-reload takes effect without
+// restarting the loop. It returns once the first composition succeeds so
+// callers can fail fast on startup.
+func (g *Gateway) Start() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	g.cancel = cancel
+
+	if err := g.refresh(ctx); err != nil {
+		cancel()
+		return fmt.Errorf("initial schema composition: %w", err)
+	}
+
+	go func() {
+		interval := time.Duration(g.refreshInterval.Load())
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := g.refresh(ctx); err != nil {
+					logrus.WithError(err).Warn("graphql schema refresh failed, keeping previous schema")
+				}
+				if next := time.Duration(g.refreshInterval.Load()); next != interval {
+					interval = next
+					ticker.Reset(interval)
+				}
+			}
+		}
+	}()
+
+	return nil
+}
+
+// HealthCheck reports unhealthy until the first schema composition
+// succeeds.
+func (g *Gateway) HealthCheck() error {
+	if g.schemaSnapshot() == nil {
+		return fmt.Errorf("graphql schema not yet composed")
+	}
+	return nil
+}
+
+// Shutdown stops the background schema refresh loop.
+func (g *Gateway) Shutdown() error {
+	if g.cancel != nil {
+		g.cancel()
+	}
+	return nil
+}
+
+func (g *Gateway) refresh(ctx context.Context) error {
+	subgraphs := *g.subgraphs.Load()
+
+	sdls := make([]*subgraphSDL, 0, len(subgraphs))
+	for _, sg := range subgraphs {
+		sdl, err := sg.FetchSDL(ctx)
+		if err != nil {
+			return err
+		}
+		sdls = append(sdls, &subgraphSDL{name: sg.Name, sdl: sdl})
+	}
+
+	sg, err := composeSchemas(sdls)
+	if err != nil {
+		return err
+	}
+
+	g.current.Store(sg)
+	logrus.WithField("subgraphs", len(subgraphs)).Info("composed graphql supergraph")
+	return nil
+}
+
+// schema returns the currently composed supergraph, or nil if no
+// composition has succeeded yet.
+func (g *Gateway) schemaSnapshot() *supergraph {
+	return g.current.Load()
+}
+
+// subgraphByName returns the Subgraph client for the given name, used when
+// a query plan needs to forward a request to it.
+func (g *Gateway) subgraphByName(name string) *Subgraph {
+	for _, sg := range *g.subgraphs.Load() {
+		if sg.Name == name {
+			return sg
+		}
+	}
+	return nil
+}
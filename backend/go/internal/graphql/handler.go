@@ -0,0 +1,488 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/formatter"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+// entityBatchWindow and entityBatchMax bound how long the gateway waits to
+// group entity-extension lookups and how many representations ride in a
+// single _entities request.
+const (
+	entityBatchWindow = 2 * time.Millisecond
+	entityBatchMax    = 100
+)
+
+// NewHandler returns a gin handler that serves POST /graphql by planning
+// the incoming query across the gateway's composed subgraphs, forwarding
+// the caller's Authorization header so each subrequest carries the same
+// JWT claims, and merging the subgraph responses back into one payload.
+func NewHandler(gw *Gateway) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req graphqlRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"errors": []gqlError{{Message: "invalid request: " + err.Error()}}})
+			return
+		}
+
+		sg := gw.schemaSnapshot()
+		if sg == nil {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"errors": []gqlError{{Message: "graphql schema not yet composed"}}})
+			return
+		}
+
+		headers := http.Header{}
+		if auth := c.GetHeader("Authorization"); auth != "" {
+			headers.Set("Authorization", auth)
+		}
+
+		data, err := gw.planAndExecute(c.Request.Context(), sg, req, headers)
+		if err != nil {
+			c.JSON(http.StatusOK, gin.H{"errors": []gqlError{{Message: err.Error()}}})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"data": data})
+	}
+}
+
+// planAndExecute groups the operation's top-level fields by the subgraph
+// that owns them, fans the sub-queries out concurrently, merges the
+// results, and then resolves any cross-subgraph entity extensions that the
+// selection asked for.
+func (g *Gateway) planAndExecute(ctx context.Context, sg *supergraph, req graphqlRequest, headers http.Header) (map[string]json.RawMessage, error) {
+	doc, err := parser.ParseQuery(&ast.Source{Input: req.Query})
+	if err != nil {
+		return nil, fmt.Errorf("parse query: %w", err)
+	}
+
+	op, err := selectOperation(doc, req.OperationName)
+	if err != nil {
+		return nil, err
+	}
+
+	rootDef, err := rootDefinition(sg.schema, op.Operation)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := map[string][]*ast.Field{}
+	for _, sel := range op.SelectionSet {
+		field, ok := sel.(*ast.Field)
+		if !ok {
+			return nil, fmt.Errorf("fragments at the root selection are not supported by the gateway planner")
+		}
+		owner := sg.fieldOwner[rootDef.Name+"."+field.Name]
+		if owner == "" {
+			return nil, fmt.Errorf("no subgraph owns field %s.%s", rootDef.Name, field.Name)
+		}
+		groups[owner] = append(groups[owner], field)
+	}
+
+	// A field whose type is resolved by a different subgraph needs its
+	// @key fields in hand to build _entities representations later, even
+	// if the client didn't select them itself. Inject any missing ones
+	// now, before the subquery is built, and remember what we added so
+	// the response can be trimmed back down to what the client asked for.
+	injectedKeys := map[*ast.Field][]string{}
+	for owner, fields := range groups {
+		for _, field := range fields {
+			fieldDef := rootDef.Fields.ForName(field.Name)
+			if fieldDef == nil {
+				continue
+			}
+			typeName := baseTypeName(fieldDef.Type)
+			extOwner := sg.entityOwner[typeName]
+			if extOwner == "" || extOwner == owner {
+				continue
+			}
+			if added := ensureEntityKeySelection(field, sg.entityKeyFields[typeName]); len(added) > 0 {
+				injectedKeys[field] = added
+			}
+		}
+	}
+
+	type ownerResult struct {
+		data map[string]json.RawMessage
+		err  error
+	}
+	resultsByOwner := make(map[string]ownerResult, len(groups))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for owner, fields := range groups {
+		owner, fields := owner, fields
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			data, err := g.executeOnSubgraph(ctx, owner, op, fields, req.Variables, headers)
+			mu.Lock()
+			resultsByOwner[owner] = ownerResult{data: data, err: err}
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	merged := map[string]json.RawMessage{}
+	for owner, res := range resultsByOwner {
+		if res.err != nil {
+			return nil, fmt.Errorf("subgraph %s: %w", owner, res.err)
+		}
+		for k, v := range res.data {
+			merged[k] = v
+		}
+	}
+
+	for owner, fields := range groups {
+		for _, field := range fields {
+			if err := g.enrichEntityExtensions(ctx, sg, rootDef, owner, field, merged, headers, injectedKeys[field]); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return merged, nil
+}
+
+// executeOnSubgraph re-serializes the subset of the operation owned by a
+// single subgraph and forwards it, along with the caller's headers. Only
+// the variable definitions (and values) actually referenced by fields is
+// forwarded; subgraphs enforce NoUnusedVariables, so sending the full
+// operation's variables would fail any query that spans subgraphs.
+func (g *Gateway) executeOnSubgraph(ctx context.Context, owner string, op *ast.OperationDefinition, fields []*ast.Field, variables map[string]interface{}, headers http.Header) (map[string]json.RawMessage, error) {
+	subgraph := g.subgraphByName(owner)
+	if subgraph == nil {
+		return nil, fmt.Errorf("unknown subgraph %q", owner)
+	}
+
+	selection := make(ast.SelectionSet, len(fields))
+	for i, f := range fields {
+		selection[i] = f
+	}
+
+	usedVars := usedVariables(selection)
+	varDefs := make(ast.VariableDefinitionList, 0, len(usedVars))
+	for _, def := range op.VariableDefinitions {
+		if usedVars[def.Variable] {
+			varDefs = append(varDefs, def)
+		}
+	}
+	subVariables := make(map[string]interface{}, len(usedVars))
+	for name := range usedVars {
+		if v, ok := variables[name]; ok {
+			subVariables[name] = v
+		}
+	}
+
+	subDoc := &ast.QueryDocument{
+		Operations: ast.OperationList{{
+			Operation:           op.Operation,
+			Name:                op.Name,
+			VariableDefinitions: varDefs,
+			SelectionSet:        selection,
+		}},
+	}
+
+	var buf bytes.Buffer
+	formatter.NewFormatter(&buf).FormatQueryDocument(subDoc)
+
+	resp, err := subgraph.execute(ctx, graphqlRequest{
+		Query:         buf.String(),
+		OperationName: op.Name,
+		Variables:     subVariables,
+	}, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	var data map[string]json.RawMessage
+	if err := json.Unmarshal(resp.Data, &data); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+	return data, nil
+}
+
+// usedVariables walks selection (including nested selections, field
+// arguments, and directives) and returns the set of variable names it
+// references, so callers can trim an operation's VariableDefinitions down
+// to the ones a particular subgraph subquery actually needs.
+func usedVariables(selection ast.SelectionSet) map[string]bool {
+	used := map[string]bool{}
+	var walkValue func(v *ast.Value)
+	walkValue = func(v *ast.Value) {
+		if v == nil {
+			return
+		}
+		if v.Kind == ast.Variable {
+			used[v.Raw] = true
+		}
+		for _, child := range v.Children {
+			walkValue(child.Value)
+		}
+	}
+	var walkDirectives func(dirs ast.DirectiveList)
+	walkDirectives = func(dirs ast.DirectiveList) {
+		for _, dir := range dirs {
+			for _, arg := range dir.Arguments {
+				walkValue(arg.Value)
+			}
+		}
+	}
+	var walk func(sel ast.SelectionSet)
+	walk = func(sel ast.SelectionSet) {
+		for _, s := range sel {
+			field, ok := s.(*ast.Field)
+			if !ok {
+				continue
+			}
+			for _, arg := range field.Arguments {
+				walkValue(arg.Value)
+			}
+			walkDirectives(field.Directives)
+			walk(field.SelectionSet)
+		}
+	}
+	walk(selection)
+	return used
+}
+
+// ensureEntityKeySelection adds any of keyFields not already present as a
+// plain (non-aliased) selection on field to field's selection set, so an
+// _entities representation can be built from the subgraph's response even
+// when the client didn't select the key fields itself. It returns the
+// field names it added, so the caller can strip them back out of the
+// response afterward.
+func ensureEntityKeySelection(field *ast.Field, keyFields []string) []string {
+	selected := map[string]bool{}
+	for _, sel := range field.SelectionSet {
+		if f, ok := sel.(*ast.Field); ok && f.Alias == "" {
+			selected[f.Name] = true
+		}
+	}
+
+	var added []string
+	for _, key := range keyFields {
+		if selected[key] {
+			continue
+		}
+		field.SelectionSet = append(field.SelectionSet, &ast.Field{Name: key})
+		added = append(added, key)
+	}
+	return added
+}
+
+// enrichEntityExtensions resolves fields in field's selection that belong
+// to a different subgraph than the one that returned field's value, by
+// looking the entity up via _entities on the owning subgraph and merging
+// the extension fields back in. It only resolves one level of extension
+// fields on the entity itself (no further nesting). headers is forwarded
+// to the owning subgraph so the _entities lookup carries the same caller
+// identity as the root query. injectedKeys lists @key fields that were
+// added to field's selection solely to build the representation and that
+// the client never asked for; they are removed from the response before
+// it's merged back in.
+func (g *Gateway) enrichEntityExtensions(ctx context.Context, sg *supergraph, rootDef *ast.Definition, rootOwner string, field *ast.Field, merged map[string]json.RawMessage, headers http.Header, injectedKeys []string) error {
+	fieldDef := rootDef.Fields.ForName(field.Name)
+	if fieldDef == nil {
+		return nil
+	}
+	typeName := baseTypeName(fieldDef.Type)
+
+	extOwner := sg.entityOwner[typeName]
+	if extOwner == "" || extOwner == rootOwner {
+		return nil
+	}
+
+	alias := field.Alias
+	if alias == "" {
+		alias = field.Name
+	}
+
+	var extFields []*ast.Field
+	for _, sel := range field.SelectionSet {
+		f, ok := sel.(*ast.Field)
+		if ok && sg.fieldOwner[typeName+"."+f.Name] == extOwner {
+			extFields = append(extFields, f)
+		}
+	}
+	if len(extFields) == 0 {
+		return stripInjectedKeys(merged, alias, injectedKeys)
+	}
+
+	keyFields := sg.entityKeyFields[typeName]
+	if len(keyFields) == 0 {
+		return fmt.Errorf("entity type %s has no usable @key for cross-subgraph resolution", typeName)
+	}
+
+	raw, ok := merged[alias]
+	if !ok {
+		return nil
+	}
+
+	entities, isList, err := decodeEntities(raw)
+	if err != nil {
+		return fmt.Errorf("decode %s for entity enrichment: %w", alias, err)
+	}
+
+	fieldNames := make([]string, len(extFields))
+	for i, f := range extFields {
+		fieldNames[i] = f.Name
+	}
+	query := fmt.Sprintf(
+		`query($representations: [_Any!]!) { _entities(representations: $representations) { ... on %s { %s } } }`,
+		typeName, strings.Join(fieldNames, " "),
+	)
+	loader := NewEntityLoader(g.subgraphByName(extOwner), query, headers, entityBatchWindow, entityBatchMax)
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(entities))
+	for i, entity := range entities {
+		i, entity := i, entity
+		representation := map[string]interface{}{"__typename": typeName}
+		for _, key := range keyFields {
+			representation[key] = entity[key]
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			extData, err := loader.Load(ctx, representation)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			var extValues map[string]interface{}
+			if err := json.Unmarshal(extData, &extValues); err != nil {
+				errs[i] = fmt.Errorf("decode entity extension: %w", err)
+				return
+			}
+			for _, name := range fieldNames {
+				entity[name] = extValues[name]
+			}
+		}()
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return fmt.Errorf("subgraph %s: resolve %s entity extension: %w", extOwner, typeName, err)
+		}
+	}
+
+	for _, entity := range entities {
+		for _, key := range injectedKeys {
+			delete(entity, key)
+		}
+	}
+
+	encoded, err := encodeEntities(entities, isList)
+	if err != nil {
+		return fmt.Errorf("encode enriched %s: %w", alias, err)
+	}
+	merged[alias] = encoded
+	return nil
+}
+
+// stripInjectedKeys removes @key fields that enrichEntityExtensions added
+// to a root subquery selection purely to build an _entities
+// representation, so they don't leak into the response when the client
+// didn't ask for them itself.
+func stripInjectedKeys(merged map[string]json.RawMessage, alias string, injectedKeys []string) error {
+	if len(injectedKeys) == 0 {
+		return nil
+	}
+	raw, ok := merged[alias]
+	if !ok {
+		return nil
+	}
+
+	entities, isList, err := decodeEntities(raw)
+	if err != nil {
+		return fmt.Errorf("decode %s for entity enrichment: %w", alias, err)
+	}
+	for _, entity := range entities {
+		for _, key := range injectedKeys {
+			delete(entity, key)
+		}
+	}
+
+	encoded, err := encodeEntities(entities, isList)
+	if err != nil {
+		return fmt.Errorf("encode enriched %s: %w", alias, err)
+	}
+	merged[alias] = encoded
+	return nil
+}
+
+func decodeEntities(raw json.RawMessage) ([]map[string]interface{}, bool, error) {
+	var list []map[string]interface{}
+	if err := json.Unmarshal(raw, &list); err == nil {
+		return list, true, nil
+	}
+
+	var obj map[string]interface{}
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return nil, false, err
+	}
+	if obj == nil {
+		return nil, false, nil
+	}
+	return []map[string]interface{}{obj}, false, nil
+}
+
+func encodeEntities(entities []map[string]interface{}, isList bool) (json.RawMessage, error) {
+	if isList {
+		return json.Marshal(entities)
+	}
+	if len(entities) == 0 {
+		return json.Marshal(nil)
+	}
+	return json.Marshal(entities[0])
+}
+
+func selectOperation(doc *ast.QueryDocument, name string) (*ast.OperationDefinition, error) {
+	if name == "" {
+		if len(doc.Operations) != 1 {
+			return nil, fmt.Errorf("operationName is required when a document defines more than one operation")
+		}
+		return doc.Operations[0], nil
+	}
+	if op := doc.Operations.ForName(name); op != nil {
+		return op, nil
+	}
+	return nil, fmt.Errorf("no operation named %q", name)
+}
+
+func rootDefinition(schema *ast.Schema, op ast.Operation) (*ast.Definition, error) {
+	switch op {
+	case ast.Query:
+		return schema.Query, nil
+	case ast.Mutation:
+		if schema.Mutation == nil {
+			return nil, fmt.Errorf("schema defines no mutations")
+		}
+		return schema.Mutation, nil
+	case ast.Subscription:
+		return nil, fmt.Errorf("subscriptions are served over /ws, not /graphql")
+	default:
+		return nil, fmt.Errorf("unknown operation type %q", op)
+	}
+}
+
+// baseTypeName unwraps list and non-null wrappers to the underlying named
+// type, e.g. [Product!]! -> Product.
+func baseTypeName(t *ast.Type) string {
+	if t.NamedType != "" {
+		return t.NamedType
+	}
+	return baseTypeName(t.Elem)
+}
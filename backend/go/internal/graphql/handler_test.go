@@ -0,0 +1,112 @@
+package graphql
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+func TestUsedVariables(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{
+			name:  "no variables",
+			query: `query { products { id name } }`,
+			want:  nil,
+		},
+		{
+			name:  "argument variable",
+			query: `query($id: ID!) { product(id: $id) { name } }`,
+			want:  []string{"id"},
+		},
+		{
+			name:  "variable nested in a selection and a directive",
+			query: `query($id: ID!, $skip: Boolean!) { product(id: $id) { name @skip(if: $skip) } }`,
+			want:  []string{"id", "skip"},
+		},
+		{
+			name:  "variable referenced only by an unrelated sibling field is still found",
+			query: `query($a: ID!, $b: ID!) { productA: product(id: $a) { name } productB: product(id: $b) { name } }`,
+			want:  []string{"a", "b"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := parser.ParseQuery(&ast.Source{Input: tt.query})
+			if err != nil {
+				t.Fatalf("parse query: %v", err)
+			}
+			op := doc.Operations[0]
+
+			got := usedVariables(op.SelectionSet)
+			var names []string
+			for name := range got {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			sort.Strings(tt.want)
+
+			if !reflect.DeepEqual(names, tt.want) {
+				t.Errorf("usedVariables() = %v, want %v", names, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnsureEntityKeySelection(t *testing.T) {
+	t.Run("adds a missing key field", func(t *testing.T) {
+		field := &ast.Field{
+			Name: "product",
+			SelectionSet: ast.SelectionSet{
+				&ast.Field{Name: "name"},
+			},
+		}
+
+		added := ensureEntityKeySelection(field, []string{"id"})
+		if !reflect.DeepEqual(added, []string{"id"}) {
+			t.Fatalf("added = %v, want [id]", added)
+		}
+		if len(field.SelectionSet) != 2 {
+			t.Fatalf("selection set = %v, want 2 fields", field.SelectionSet)
+		}
+	})
+
+	t.Run("leaves an already-selected key field alone", func(t *testing.T) {
+		field := &ast.Field{
+			Name: "product",
+			SelectionSet: ast.SelectionSet{
+				&ast.Field{Name: "id"},
+				&ast.Field{Name: "name"},
+			},
+		}
+
+		added := ensureEntityKeySelection(field, []string{"id"})
+		if added != nil {
+			t.Fatalf("added = %v, want nil", added)
+		}
+		if len(field.SelectionSet) != 2 {
+			t.Fatalf("selection set = %v, want unchanged 2 fields", field.SelectionSet)
+		}
+	})
+
+	t.Run("an aliased key selection doesn't count as selected", func(t *testing.T) {
+		field := &ast.Field{
+			Name: "product",
+			SelectionSet: ast.SelectionSet{
+				&ast.Field{Name: "id", Alias: "productID"},
+			},
+		}
+
+		added := ensureEntityKeySelection(field, []string{"id"})
+		if !reflect.DeepEqual(added, []string{"id"}) {
+			t.Fatalf("added = %v, want [id]", added)
+		}
+	})
+}
@@ -0,0 +1,13 @@
+package graphql
+
+import (
+	"github.com/99designs/gqlgen/graphql/playground"
+	"github.com/gin-gonic/gin"
+)
+
+// NewPlaygroundHandler serves the GraphQL Playground UI pointed at the
+// gateway's /graphql endpoint.
+func NewPlaygroundHandler() gin.HandlerFunc {
+	handler := playground.Handler("Olympus GraphQL Gateway", "/graphql")
+	return gin.WrapH(handler)
+}
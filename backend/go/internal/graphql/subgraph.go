@@ -0,0 +1,117 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/olympuscloud/olympus-cloud-gcp/backend/go/internal/metrics"
+	"github.com/olympuscloud/olympus-cloud-gcp/backend/go/internal/tracing"
+)
+
+// Subgraph is a single federated GraphQL service (Rust auth, Python
+// analytics, or the commerce service) that the gateway composes into one
+// schema and forwards queries to.
+type Subgraph struct {
+	Name   string
+	URL    string
+	client *http.Client
+}
+
+// NewSubgraph builds a Subgraph with a client tuned for schema polling and
+// query forwarding, both of which should fail fast rather than hang the
+// gateway.
+func NewSubgraph(name, url string) *Subgraph {
+	return &Subgraph{
+		Name: name,
+		URL:  url,
+		client: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+const serviceSDLQuery = `{ _service { sdl } }`
+
+type graphqlRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+type graphqlResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []gqlError      `json:"errors,omitempty"`
+}
+
+type gqlError struct {
+	Message string `json:"message"`
+}
+
+// FetchSDL retrieves the subgraph's schema via the Apollo Federation
+// `_service { sdl }` field so the gateway can recompose its supergraph
+// without the subgraph owners publishing schema files out of band.
+func (s *Subgraph) FetchSDL(ctx context.Context) (string, error) {
+	body, err := s.execute(ctx, graphqlRequest{Query: serviceSDLQuery}, nil)
+	if err != nil {
+		return "", fmt.Errorf("subgraph %s: fetch sdl: %w", s.Name, err)
+	}
+
+	var payload struct {
+		Service struct {
+			SDL string `json:"sdl"`
+		} `json:"_service"`
+	}
+	if err := json.Unmarshal(body.Data, &payload); err != nil {
+		return "", fmt.Errorf("subgraph %s: decode sdl response: %w", s.Name, err)
+	}
+	return payload.Service.SDL, nil
+}
+
+// execute POSTs a GraphQL request to the subgraph and forwards the given
+// headers (used to propagate JWT claims on subrequests).
+func (s *Subgraph) execute(ctx context.Context, req graphqlRequest, headers http.Header) (*graphqlResponse, error) {
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("encode request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("build request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	for key, values := range headers {
+		for _, value := range values {
+			httpReq.Header.Add(key, value)
+		}
+	}
+
+	ctx, span := tracing.StartClientSpan(ctx, "subgraph "+s.Name, httpReq)
+	httpReq = httpReq.WithContext(ctx)
+
+	start := time.Now()
+	resp, err := s.client.Do(httpReq)
+	metrics.ObserveUpstream(s.Name, time.Since(start), err)
+	tracing.EndClientSpan(span, err)
+	if err != nil {
+		return nil, fmt.Errorf("do request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	var out graphqlResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+	if len(out.Errors) > 0 {
+		return nil, fmt.Errorf("subgraph returned errors: %s", out.Errors[0].Message)
+	}
+	return &out, nil
+}
@@ -0,0 +1,36 @@
+package logging
+
+import (
+	"github.com/samber/do"
+	"github.com/sirupsen/logrus"
+
+	"github.com/olympuscloud/olympus-cloud-gcp/backend/go/internal/config"
+)
+
+// NewService configures the process-wide logrus logger from Config and
+// returns the standard logger so other services can take it as a
+// dependency instead of reaching for the package-level logrus functions.
+//
+// It also subscribes to the *config.Store so LOG_LEVEL takes effect
+// immediately on a hot-reload, without requiring a restart.
+func NewService(i *do.Injector) (*logrus.Logger, error) {
+	store := do.MustInvoke[*config.Store](i)
+
+	applyLevel(store.Current())
+	store.Subscribe(applyLevel)
+
+	logrus.SetFormatter(&logrus.JSONFormatter{})
+
+	return logrus.StandardLogger(), nil
+}
+
+// applyLevel sets the process-wide logrus level from cfg.LogLevel,
+// falling back to info on an unrecognized value.
+func applyLevel(cfg *config.Config) {
+	level, err := logrus.ParseLevel(cfg.LogLevel)
+	if err != nil {
+		level = logrus.InfoLevel
+	}
+
+	logrus.SetLevel(level)
+}
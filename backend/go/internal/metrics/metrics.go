@@ -0,0 +1,110 @@
+// Package metrics registers the gateway's Prometheus collectors: RED
+// (rate/errors/duration) metrics for every Gin route, plus counters and a
+// histogram for the proxy handlers' upstream calls.
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	requestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "Total HTTP requests handled by the gateway, labeled by route template.",
+	}, []string{"method", "route", "status_class"})
+
+	requestsInFlight = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "http_requests_in_flight",
+		Help: "Number of HTTP requests currently being served by the gateway.",
+	})
+
+	requestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "HTTP request latency in seconds, labeled by route template.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route", "status_class"})
+
+	upstreamRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "upstream_requests_total",
+		Help: "Total requests the gateway made to a downstream service.",
+	}, []string{"service"})
+
+	upstreamErrorsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "upstream_errors_total",
+		Help: "Total failed requests the gateway made to a downstream service.",
+	}, []string{"service"})
+
+	upstreamLatencySeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "upstream_latency_seconds",
+		Help:    "Latency of gateway requests to a downstream service, in seconds.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"service"})
+)
+
+func init() {
+	prometheus.MustRegister(
+		requestsTotal,
+		requestsInFlight,
+		requestDuration,
+		upstreamRequestsTotal,
+		upstreamErrorsTotal,
+		upstreamLatencySeconds,
+	)
+}
+
+// wsRoutePath is the long-lived WebSocket upgrade route. Middleware skips
+// RED accounting for it: a socket held open for hours would otherwise
+// count as a single request stuck in flight and inflate the latency
+// histogram with a multi-hour observation, for the entire life of the
+// connection. ws_connections_active/ws_messages_* (internal/ws) cover that
+// traffic instead.
+const wsRoutePath = "/ws"
+
+// Middleware records request count, in-flight gauge, and latency for every
+// request, labeled by route template (c.FullPath, e.g. "/api/v1/commerce/*path")
+// rather than the raw path, so path parameters don't blow up cardinality.
+// It skips wsRoutePath -- see its doc comment.
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.FullPath() == wsRoutePath {
+			c.Next()
+			return
+		}
+
+		requestsInFlight.Inc()
+		start := time.Now()
+
+		c.Next()
+
+		requestsInFlight.Dec()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		statusClass := strconv.Itoa(c.Writer.Status()/100) + "xx"
+
+		labels := prometheus.Labels{
+			"method":       c.Request.Method,
+			"route":        route,
+			"status_class": statusClass,
+		}
+		requestsTotal.With(labels).Inc()
+		requestDuration.With(labels).Observe(time.Since(start).Seconds())
+	}
+}
+
+// ObserveUpstream records a proxied call to a downstream service (the Rust
+// commerce/auth service, the Python analytics service, a GraphQL
+// subgraph, ...), keyed by a short service name.
+func ObserveUpstream(service string, duration time.Duration, err error) {
+	upstreamRequestsTotal.WithLabelValues(service).Inc()
+	upstreamLatencySeconds.WithLabelValues(service).Observe(duration.Seconds())
+	if err != nil {
+		upstreamErrorsTotal.WithLabelValues(service).Inc()
+	}
+}
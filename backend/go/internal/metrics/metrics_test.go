@@ -0,0 +1,29 @@
+package metrics
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMiddlewareSkipsWebSocketRoute(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(Middleware())
+	engine.GET("/ws", func(c *gin.Context) { c.Status(200) })
+	engine.GET("/api/v1/ping", func(c *gin.Context) { c.Status(200) })
+
+	before := testutil.ToFloat64(requestsTotal.WithLabelValues("GET", "/api/v1/ping", "2xx"))
+
+	engine.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/ws", nil))
+	if got := testutil.ToFloat64(requestsTotal.WithLabelValues("GET", wsRoutePath, "2xx")); got != 0 {
+		t.Fatalf("requestsTotal for %s = %v, want 0 (should be skipped)", wsRoutePath, got)
+	}
+
+	engine.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/api/v1/ping", nil))
+	if got := testutil.ToFloat64(requestsTotal.WithLabelValues("GET", "/api/v1/ping", "2xx")); got != before+1 {
+		t.Fatalf("requestsTotal for /api/v1/ping = %v, want %v (should be recorded)", got, before+1)
+	}
+}
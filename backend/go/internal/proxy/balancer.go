@@ -0,0 +1,38 @@
+package proxy
+
+import "sync/atomic"
+
+// LoadBalancer picks one target from a slice that Pool has already
+// filtered down to healthy, breaker-closed candidates.
+type LoadBalancer interface {
+	Pick(targets []*Target) *Target
+}
+
+// RoundRobin cycles through targets in order.
+type RoundRobin struct {
+	counter uint64
+}
+
+func (r *RoundRobin) Pick(targets []*Target) *Target {
+	if len(targets) == 0 {
+		return nil
+	}
+	n := atomic.AddUint64(&r.counter, 1)
+	return targets[(n-1)%uint64(len(targets))]
+}
+
+// LeastConnections picks the target with the fewest in-flight requests.
+type LeastConnections struct{}
+
+func (LeastConnections) Pick(targets []*Target) *Target {
+	if len(targets) == 0 {
+		return nil
+	}
+	best := targets[0]
+	for _, t := range targets[1:] {
+		if t.activeConns.Load() < best.activeConns.Load() {
+			best = t
+		}
+	}
+	return best
+}
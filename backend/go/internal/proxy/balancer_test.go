@@ -0,0 +1,49 @@
+package proxy
+
+import "testing"
+
+func mustTarget(t *testing.T, rawURL string) *Target {
+	t.Helper()
+	target, err := newTarget(rawURL)
+	if err != nil {
+		t.Fatalf("newTarget(%q): %v", rawURL, err)
+	}
+	return target
+}
+
+func TestRoundRobinPick(t *testing.T) {
+	if got := (&RoundRobin{}).Pick(nil); got != nil {
+		t.Fatalf("Pick() on empty targets = %v, want nil", got)
+	}
+
+	a := mustTarget(t, "http://a")
+	b := mustTarget(t, "http://b")
+	targets := []*Target{a, b}
+
+	rr := &RoundRobin{}
+	got := []*Target{rr.Pick(targets), rr.Pick(targets), rr.Pick(targets), rr.Pick(targets)}
+	want := []*Target{a, b, a, b}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Pick() call %d = %v, want %v", i, got[i].URL, want[i].URL)
+		}
+	}
+}
+
+func TestLeastConnectionsPick(t *testing.T) {
+	if got := (LeastConnections{}).Pick(nil); got != nil {
+		t.Fatalf("Pick() on empty targets = %v, want nil", got)
+	}
+
+	a := mustTarget(t, "http://a")
+	b := mustTarget(t, "http://b")
+	c := mustTarget(t, "http://c")
+	a.activeConns.Store(3)
+	b.activeConns.Store(1)
+	c.activeConns.Store(2)
+
+	got := (LeastConnections{}).Pick([]*Target{a, b, c})
+	if got != b {
+		t.Fatalf("Pick() = %v, want the target with the fewest active connections (%v)", got.URL, b.URL)
+	}
+}
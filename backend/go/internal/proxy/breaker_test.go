@@ -0,0 +1,83 @@
+package proxy
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCircuitBreakerTransitions(t *testing.T) {
+	t.Run("closed allows calls and opens after the failure threshold", func(t *testing.T) {
+		b := NewCircuitBreaker(2, time.Minute)
+
+		if !b.Allow() {
+			t.Fatal("Allow() = false, want true while closed")
+		}
+
+		b.Failure()
+		if !b.Allow() {
+			t.Fatal("Allow() = false, want true after one failure (threshold not reached)")
+		}
+
+		b.Failure()
+		if b.Allow() {
+			t.Fatal("Allow() = true, want false once the failure threshold is reached")
+		}
+	})
+
+	t.Run("open rejects calls until resetTimeout elapses", func(t *testing.T) {
+		b := NewCircuitBreaker(1, 20*time.Millisecond)
+		b.Failure()
+
+		if b.Allow() {
+			t.Fatal("Allow() = true, want false immediately after opening")
+		}
+
+		time.Sleep(30 * time.Millisecond)
+		if !b.Allow() {
+			t.Fatal("Allow() = false, want true for the half-open trial once resetTimeout elapses")
+		}
+	})
+
+	t.Run("half-open admits exactly one trial call", func(t *testing.T) {
+		b := NewCircuitBreaker(1, 10*time.Millisecond)
+		b.Failure()
+		time.Sleep(20 * time.Millisecond)
+
+		if !b.Allow() {
+			t.Fatal("Allow() = false, want true for the first half-open caller")
+		}
+		if b.Allow() {
+			t.Fatal("Allow() = true, want false for a concurrent caller while a trial is in flight")
+		}
+	})
+
+	t.Run("a successful trial closes the breaker", func(t *testing.T) {
+		b := NewCircuitBreaker(1, 10*time.Millisecond)
+		b.Failure()
+		time.Sleep(20 * time.Millisecond)
+
+		if !b.Allow() {
+			t.Fatal("Allow() = false, want true for the trial")
+		}
+		b.Success()
+
+		if !b.Allow() {
+			t.Fatal("Allow() = false, want true once closed again")
+		}
+	})
+
+	t.Run("a failed trial re-opens the breaker", func(t *testing.T) {
+		b := NewCircuitBreaker(1, 10*time.Millisecond)
+		b.Failure()
+		time.Sleep(20 * time.Millisecond)
+
+		if !b.Allow() {
+			t.Fatal("Allow() = false, want true for the trial")
+		}
+		b.Failure()
+
+		if b.Allow() {
+			t.Fatal("Allow() = true, want false immediately after the trial fails and re-opens")
+		}
+	})
+}
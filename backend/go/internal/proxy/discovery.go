@@ -0,0 +1,133 @@
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	consul "github.com/hashicorp/consul/api"
+	clientv3 "go.etcd.io/etcd/client/v3"
+
+	"github.com/olympuscloud/olympus-cloud-gcp/backend/go/internal/config"
+)
+
+// Discovery resolves the current set of upstream URLs for a backend
+// service. It is polled on a timer by Pool, so implementations should be
+// cheap and side-effect free.
+type Discovery interface {
+	Resolve(ctx context.Context) ([]string, error)
+}
+
+// StaticDiscovery always returns the same fixed list of upstream URLs.
+type StaticDiscovery struct {
+	URLs []string
+}
+
+func (s StaticDiscovery) Resolve(_ context.Context) ([]string, error) {
+	return s.URLs, nil
+}
+
+// ConfigDiscovery re-reads the upstream list from a config.Store on every
+// poll, so a hot-reloaded change to the URLs takes effect on Pool's next
+// discovery tick without restarting the gateway.
+type ConfigDiscovery struct {
+	Store *config.Store
+	URLs  func(*config.Config) []string
+}
+
+func (c ConfigDiscovery) Resolve(_ context.Context) ([]string, error) {
+	return c.URLs(c.Store.Current()), nil
+}
+
+// DNSDiscovery resolves upstreams from a DNS SRV record, e.g.
+// `_commerce._tcp.service.consul`. Each SRV target/port pair becomes one
+// http(s) upstream URL.
+type DNSDiscovery struct {
+	Service string
+	Proto   string
+	Name    string
+	Scheme  string
+}
+
+func (d DNSDiscovery) Resolve(ctx context.Context) ([]string, error) {
+	_, records, err := net.DefaultResolver.LookupSRV(ctx, d.Service, d.Proto, d.Name)
+	if err != nil {
+		return nil, fmt.Errorf("lookup SRV _%s._%s.%s: %w", d.Service, d.Proto, d.Name, err)
+	}
+
+	scheme := d.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+
+	urls := make([]string, 0, len(records))
+	for _, rec := range records {
+		target := strings.TrimSuffix(rec.Target, ".")
+		u := url.URL{Scheme: scheme, Host: fmt.Sprintf("%s:%d", target, rec.Port)}
+		urls = append(urls, u.String())
+	}
+	return urls, nil
+}
+
+// ConsulDiscovery resolves upstreams from a Consul service's healthy
+// instances, via the catalog's `/health/service/<name>` endpoint.
+type ConsulDiscovery struct {
+	Client  *consul.Client
+	Service string
+	Tag     string
+	Scheme  string
+}
+
+func (c ConsulDiscovery) Resolve(ctx context.Context) ([]string, error) {
+	entries, _, err := c.Client.Health().ServiceMultipleTags(c.Service, tagsOrNil(c.Tag), true, (&consul.QueryOptions{}).WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("consul health service %s: %w", c.Service, err)
+	}
+
+	scheme := c.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+
+	urls := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		addr := entry.Service.Address
+		if addr == "" {
+			addr = entry.Node.Address
+		}
+		u := url.URL{Scheme: scheme, Host: fmt.Sprintf("%s:%d", addr, entry.Service.Port)}
+		urls = append(urls, u.String())
+	}
+	return urls, nil
+}
+
+func tagsOrNil(tag string) []string {
+	if tag == "" {
+		return nil
+	}
+	return []string{tag}
+}
+
+// EtcdDiscovery resolves upstreams from the values stored under an etcd
+// key prefix, one upstream URL per key -- the shape a service registers
+// itself under on startup (e.g. `/services/commerce/<instance-id>` ->
+// `http://10.0.1.4:3001`).
+type EtcdDiscovery struct {
+	Client *clientv3.Client
+	Prefix string
+}
+
+func (e EtcdDiscovery) Resolve(ctx context.Context) ([]string, error) {
+	resp, err := e.Client.Get(ctx, e.Prefix, clientv3.WithPrefix())
+	if err != nil {
+		return nil, fmt.Errorf("etcd get prefix %s: %w", e.Prefix, err)
+	}
+
+	urls := make([]string, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		urls = append(urls, string(kv.Value))
+	}
+	return urls, nil
+}
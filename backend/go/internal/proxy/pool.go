@@ -0,0 +1,205 @@
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Target is one upstream instance of a backend service.
+type Target struct {
+	URL *url.URL
+
+	healthy     atomic.Bool
+	activeConns atomic.Int64
+	breaker     *CircuitBreaker
+}
+
+func newTarget(rawURL string) (*Target, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse upstream URL %q: %w", rawURL, err)
+	}
+	t := &Target{URL: u, breaker: NewCircuitBreaker(5, 30*time.Second)}
+	t.healthy.Store(true)
+	return t, nil
+}
+
+// Pool discovers, health-checks, and load-balances the upstream instances
+// of a single backend service.
+type Pool struct {
+	name       string
+	discovery  Discovery
+	balancer   LoadBalancer
+	healthPath string
+	client     *http.Client
+
+	discoveryInterval   time.Duration
+	healthCheckInterval time.Duration
+
+	mu      sync.RWMutex
+	targets map[string]*Target
+
+	cancel context.CancelFunc
+}
+
+// NewPool builds a Pool. Call Start to begin discovery/health-check
+// polling before routing any traffic through it.
+func NewPool(name string, discovery Discovery, balancer LoadBalancer, healthPath string, discoveryInterval, healthCheckInterval time.Duration) *Pool {
+	return &Pool{
+		name:                name,
+		discovery:           discovery,
+		balancer:            balancer,
+		healthPath:          healthPath,
+		client:              &http.Client{Timeout: 5 * time.Second},
+		discoveryInterval:   discoveryInterval,
+		healthCheckInterval: healthCheckInterval,
+		targets:             map[string]*Target{},
+	}
+}
+
+// Start resolves the initial target list and begins the discovery and
+// health-check polling loops.
+func (p *Pool) Start() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+
+	if err := p.discover(ctx); err != nil {
+		cancel()
+		return fmt.Errorf("initial discovery for %s: %w", p.name, err)
+	}
+	p.checkHealth(ctx)
+
+	go p.loop(ctx, p.discoveryInterval, p.discover)
+	go p.loop(ctx, p.healthCheckInterval, func(ctx context.Context) error {
+		p.checkHealth(ctx)
+		return nil
+	})
+
+	return nil
+}
+
+func (p *Pool) loop(ctx context.Context, interval time.Duration, fn func(context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := fn(ctx); err != nil {
+				logrus.WithError(err).WithField("pool", p.name).Warn("proxy pool background task failed")
+			}
+		}
+	}
+}
+
+// discover asks Discovery for the current upstream list and reconciles it
+// against the existing target map, preserving health/breaker state for
+// upstreams that are still present.
+func (p *Pool) discover(ctx context.Context) error {
+	urls, err := p.discovery.Resolve(ctx)
+	if err != nil {
+		return err
+	}
+
+	next := make(map[string]*Target, len(urls))
+	p.mu.RLock()
+	for _, raw := range urls {
+		if existing, ok := p.targets[raw]; ok {
+			next[raw] = existing
+			continue
+		}
+		target, err := newTarget(raw)
+		if err != nil {
+			p.mu.RUnlock()
+			return err
+		}
+		next[raw] = target
+	}
+	p.mu.RUnlock()
+
+	p.mu.Lock()
+	p.targets = next
+	p.mu.Unlock()
+	return nil
+}
+
+// checkHealth probes every known target's health endpoint and updates its
+// healthy flag. Probe failures are not fatal to the loop; an unhealthy
+// target is simply excluded from Next until it recovers.
+func (p *Pool) checkHealth(ctx context.Context) {
+	p.mu.RLock()
+	targets := make([]*Target, 0, len(p.targets))
+	for _, t := range p.targets {
+		targets = append(targets, t)
+	}
+	p.mu.RUnlock()
+
+	var wg sync.WaitGroup
+	for _, t := range targets {
+		t := t
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			t.healthy.Store(p.probe(ctx, t))
+		}()
+	}
+	wg.Wait()
+}
+
+func (p *Pool) probe(ctx context.Context, t *Target) bool {
+	reqURL := t.URL.ResolveReference(&url.URL{Path: p.healthPath})
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL.String(), nil)
+	if err != nil {
+		return false
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return false
+	}
+	defer resp.Body.Close()
+	return resp.StatusCode < http.StatusInternalServerError
+}
+
+// Next returns the next target to route a request to, per the configured
+// load-balancing strategy, skipping unhealthy targets and ones whose
+// circuit breaker is currently open.
+func (p *Pool) Next() (*Target, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	candidates := make([]*Target, 0, len(p.targets))
+	for _, t := range p.targets {
+		if t.healthy.Load() && t.breaker.Allow() {
+			candidates = append(candidates, t)
+		}
+	}
+
+	target := p.balancer.Pick(candidates)
+	if target == nil {
+		return nil, fmt.Errorf("no healthy upstreams available for %s", p.name)
+	}
+	return target, nil
+}
+
+// HealthCheck satisfies do.Healthcheckable: the pool is unhealthy once it
+// has no target left to route to.
+func (p *Pool) HealthCheck() error {
+	_, err := p.Next()
+	return err
+}
+
+// Shutdown stops the discovery and health-check loops.
+func (p *Pool) Shutdown() error {
+	if p.cancel != nil {
+		p.cancel()
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/olympuscloud/olympus-cloud-gcp/backend/go/internal/metrics"
+	"github.com/olympuscloud/olympus-cloud-gcp/backend/go/internal/tracing"
+)
+
+// Proxy forwards requests to whichever Pool target is picked next,
+// retrying on a different target (with exponential backoff) when a
+// subrequest fails before any response bytes reach the client.
+type Proxy struct {
+	pool       *Pool
+	maxRetries int
+	baseDelay  time.Duration
+}
+
+// NewProxy wraps pool with retry policy maxRetries (additional attempts
+// beyond the first) and baseDelay as the starting exponential backoff.
+func NewProxy(pool *Pool, maxRetries int, baseDelay time.Duration) *Proxy {
+	return &Proxy{pool: pool, maxRetries: maxRetries, baseDelay: baseDelay}
+}
+
+// Handler returns a gin handler that proxies everything under the route's
+// `path` wildcard param to the pool.
+func (p *Proxy) Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Buffered so it can be replayed against a different upstream on
+		// retry; the response body itself is still streamed straight
+		// through via httputil.ReverseProxy.
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "failed to read request body"})
+			return
+		}
+
+		// Captured once so every attempt's client span is a sibling under
+		// the inbound request span, rather than nesting under whichever
+		// attempt came before it.
+		requestCtx := c.Request.Context()
+
+		var lastErr error
+		for attempt := 0; attempt <= p.maxRetries; attempt++ {
+			if attempt > 0 {
+				time.Sleep(p.baseDelay * time.Duration(1<<(attempt-1)))
+			}
+
+			target, err := p.pool.Next()
+			if err != nil {
+				c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+				return
+			}
+
+			target.activeConns.Add(1)
+			err = p.forward(requestCtx, c, target, body)
+			target.activeConns.Add(-1)
+
+			if err == nil {
+				target.breaker.Success()
+				return
+			}
+
+			target.breaker.Failure()
+			lastErr = err
+
+			if c.Writer.Written() {
+				// The upstream failed mid-stream, after bytes already
+				// reached the client: the response is underway and
+				// can't be replayed against another target.
+				logrus.WithError(err).WithField("upstream", target.URL.String()).Warn("upstream request failed after response started, not retrying")
+				return
+			}
+
+			logrus.WithError(err).WithField("upstream", target.URL.String()).Warn("upstream request failed, retrying")
+		}
+
+		c.JSON(http.StatusBadGateway, gin.H{"error": "all upstreams failed", "detail": lastErr.Error()})
+	}
+}
+
+// forward proxies a single attempt to target, returning non-nil only if
+// the subrequest itself failed (connection refused, timeout, ...) before
+// any response was written -- in which case it is safe to retry.
+func (p *Proxy) forward(ctx context.Context, c *gin.Context, target *Target, body []byte) error {
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	c.Request.ContentLength = int64(len(body))
+
+	start := time.Now()
+	attemptCtx, span := tracing.StartClientSpan(ctx, "proxy "+p.pool.name, &http.Request{URL: target.URL, Header: c.Request.Header})
+	c.Request = c.Request.WithContext(attemptCtx)
+
+	var proxyErr error
+	defer func() {
+		metrics.ObserveUpstream(p.pool.name, time.Since(start), proxyErr)
+		tracing.EndClientSpan(span, proxyErr)
+	}()
+
+	rp := &httputil.ReverseProxy{
+		Director: func(req *http.Request) {
+			req.URL.Scheme = target.URL.Scheme
+			req.URL.Host = target.URL.Host
+			req.URL.Path = "/" + strings.TrimPrefix(c.Param("path"), "/")
+			req.Host = target.URL.Host
+
+			req.Header.Set("X-Forwarded-Host", c.Request.Host)
+			req.Header.Set("X-Forwarded-Proto", forwardedProto(c.Request))
+			req.Header.Add("X-Forwarded-For", c.ClientIP())
+		},
+		ErrorHandler: func(_ http.ResponseWriter, _ *http.Request, err error) {
+			proxyErr = err
+		},
+	}
+
+	rp.ServeHTTP(c.Writer, c.Request)
+	return proxyErr
+}
+
+func forwardedProto(r *http.Request) string {
+	if r.TLS != nil {
+		return "https"
+	}
+	return "http"
+}
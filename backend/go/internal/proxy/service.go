@@ -0,0 +1,33 @@
+package proxy
+
+import (
+	"time"
+
+	"github.com/samber/do"
+
+	"github.com/olympuscloud/olympus-cloud-gcp/backend/go/internal/config"
+)
+
+// NewCommerceService builds and starts the load-balanced pool for the Rust
+// commerce service from Config.CommerceServiceURLs, so it can be
+// registered with container.New like any other dependency.
+//
+// Discovery reads through the config Store rather than a fixed snapshot,
+// so adding or removing a target in CommerceServiceURLs takes effect on
+// Pool's next discovery tick on a hot-reload, without a restart.
+func NewCommerceService(i *do.Injector) (*Pool, error) {
+	store := do.MustInvoke[*config.Store](i)
+
+	pool := NewPool(
+		"commerce",
+		ConfigDiscovery{Store: store, URLs: func(cfg *config.Config) []string { return cfg.CommerceServiceURLs }},
+		&RoundRobin{},
+		"/health",
+		30*time.Second,
+		10*time.Second,
+	)
+	if err := pool.Start(); err != nil {
+		return nil, err
+	}
+	return pool, nil
+}
@@ -0,0 +1,52 @@
+package redisclient
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/samber/do"
+
+	"github.com/olympuscloud/olympus-cloud-gcp/backend/go/internal/config"
+)
+
+// Service wraps a redis.Client so it can implement the do lifecycle
+// interfaces; *redis.Client itself can't have methods added to it from
+// outside the redis package.
+type Service struct {
+	*redis.Client
+}
+
+// NewService builds the shared Redis client from Config, preferring
+// REDIS_URL when set and otherwise assembling the address from the
+// individual host/port/password/db fields.
+func NewService(i *do.Injector) (*Service, error) {
+	cfg := do.MustInvoke[*config.Config](i)
+
+	var opts *redis.Options
+	if cfg.RedisURL != "" {
+		parsed, err := redis.ParseURL(cfg.RedisURL)
+		if err != nil {
+			return nil, fmt.Errorf("parse REDIS_URL: %w", err)
+		}
+		opts = parsed
+	} else {
+		opts = &redis.Options{
+			Addr:     fmt.Sprintf("%s:%d", cfg.RedisHost, cfg.RedisPort),
+			Password: cfg.RedisPassword,
+			DB:       cfg.RedisDB,
+		}
+	}
+
+	return &Service{Client: redis.NewClient(opts)}, nil
+}
+
+// HealthCheck pings Redis so /health reflects a broken connection.
+func (s *Service) HealthCheck() error {
+	return s.Client.Ping(context.Background()).Err()
+}
+
+// Shutdown closes the underlying connection pool.
+func (s *Service) Shutdown() error {
+	return s.Client.Close()
+}
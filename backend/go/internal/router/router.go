@@ -0,0 +1,49 @@
+package router
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/samber/do"
+	"github.com/sirupsen/logrus"
+
+	"github.com/olympuscloud/olympus-cloud-gcp/backend/go/internal/metrics"
+	"github.com/olympuscloud/olympus-cloud-gcp/backend/go/internal/tracing"
+)
+
+// NewService builds the base Gin engine with recovery, tracing,
+// RED-metrics, and structured request logging middleware wired in, in that
+// order so the request's trace ID is already set by the time it's logged.
+// Route registration happens in main, once the other services the gateway
+// depends on are available to bind into handlers.
+func NewService(_ *do.Injector) (*gin.Engine, error) {
+	gin.SetMode(gin.ReleaseMode)
+
+	engine := gin.New()
+	engine.Use(gin.Recovery())
+	engine.Use(tracing.Middleware())
+	engine.Use(metrics.Middleware())
+	engine.Use(requestLogger())
+
+	return engine, nil
+}
+
+func requestLogger() gin.HandlerFunc {
+	return gin.LoggerWithFormatter(func(param gin.LogFormatterParams) string {
+		fields := logrus.Fields{
+			"timestamp":  param.TimeStamp.Format(time.RFC3339),
+			"status":     param.StatusCode,
+			"latency":    param.Latency,
+			"client_ip":  param.ClientIP,
+			"method":     param.Method,
+			"path":       param.Path,
+			"user_agent": param.Request.UserAgent(),
+			"error":      param.ErrorMessage,
+		}
+		if traceID, ok := param.Keys[tracing.TraceIDKey].(string); ok && traceID != "" {
+			fields[tracing.TraceIDKey] = traceID
+		}
+		logrus.WithFields(fields).Info("HTTP Request")
+		return ""
+	})
+}
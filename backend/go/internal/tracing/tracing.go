@@ -0,0 +1,145 @@
+// Package tracing configures the gateway's OpenTelemetry TracerProvider:
+// an OTLP/HTTP exporter (when OTEL_EXPORTER_OTLP_ENDPOINT is set) or a
+// no-op exporter otherwise, a ratio-based sampler, and the W3C tracecontext
+// propagator used to forward traceparent headers to downstream services.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/samber/do"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/olympuscloud/olympus-cloud-gcp/backend/go/internal/config"
+)
+
+// Provider wraps a tracesdk.TracerProvider so it can implement the do
+// lifecycle interfaces; it also exposes Tracer for handlers and outbound
+// clients that want to start their own spans.
+type Provider struct {
+	*tracesdk.TracerProvider
+}
+
+// NewService builds the gateway's TracerProvider from Config, registers it
+// as the global provider, and installs the W3C tracecontext propagator so
+// traceparent headers flow through the proxy and GraphQL subgraph clients.
+// Fitting the do.Provider[*Provider] shape lets it be registered with
+// container.New like any other service.
+func NewService(i *do.Injector) (*Provider, error) {
+	cfg := do.MustInvoke[*config.Config](i)
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		semconv.ServiceName(cfg.OTelServiceName),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("build resource: %w", err)
+	}
+
+	opts := []tracesdk.TracerProviderOption{
+		tracesdk.WithResource(res),
+		tracesdk.WithSampler(tracesdk.ParentBased(tracesdk.TraceIDRatioBased(cfg.OTelSamplerRatio))),
+	}
+
+	if cfg.OTelExporterOTLPEndpoint != "" {
+		exporterOpts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(cfg.OTelExporterOTLPEndpoint)}
+		if cfg.OTelExporterInsecure {
+			exporterOpts = append(exporterOpts, otlptracehttp.WithInsecure())
+		}
+
+		exporter, err := otlptracehttp.New(context.Background(), exporterOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("build otlp exporter: %w", err)
+		}
+		opts = append(opts, tracesdk.WithBatcher(exporter))
+	}
+
+	tp := tracesdk.NewTracerProvider(opts...)
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	return &Provider{TracerProvider: tp}, nil
+}
+
+// Shutdown flushes and stops the TracerProvider.
+func (p *Provider) Shutdown() error {
+	return p.TracerProvider.Shutdown(context.Background())
+}
+
+// Tracer returns the gateway's tracer, named after this package so spans
+// are easy to trace back to where they were started.
+func Tracer() trace.Tracer {
+	return otel.Tracer("github.com/olympuscloud/olympus-cloud-gcp/backend/go/internal/tracing")
+}
+
+// TraceIDKey is the gin-context key Middleware stashes the request's trace
+// ID under, so requestLogger can correlate logs with traces.
+const TraceIDKey = "trace_id"
+
+// wsRoutePath is the long-lived WebSocket upgrade route. Middleware skips
+// it: a span that stays open for the lifetime of a socket would otherwise
+// show up as one multi-hour server span per connection, indistinguishable
+// from a hung request in the trace backend.
+const wsRoutePath = "/ws"
+
+// Middleware extracts an incoming traceparent header (if any), starts a
+// server span for the request, and stashes its trace ID under TraceIDKey in
+// the gin context so requestLogger can correlate logs with traces. It skips
+// wsRoutePath -- see its doc comment.
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.FullPath() == wsRoutePath {
+			c.Next()
+			return
+		}
+
+		ctx := otel.GetTextMapPropagator().Extract(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header))
+
+		ctx, span := Tracer().Start(ctx, c.Request.Method+" "+c.FullPath(), trace.WithSpanKind(trace.SpanKindServer))
+		defer span.End()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Set(TraceIDKey, span.SpanContext().TraceID().String())
+
+		c.Next()
+
+		status := c.Writer.Status()
+		span.SetAttributes(
+			semconv.HTTPMethod(c.Request.Method),
+			semconv.HTTPRoute(c.FullPath()),
+			semconv.HTTPStatusCode(status),
+		)
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+	}
+}
+
+// StartClientSpan starts a span for an outbound call to a downstream
+// service and injects the W3C traceparent header into req so the callee
+// can continue the same trace.
+func StartClientSpan(ctx context.Context, name string, req *http.Request) (context.Context, trace.Span) {
+	ctx, span := Tracer().Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("http.url", req.URL.String())))
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+	return ctx, span
+}
+
+// EndClientSpan records err (if any) on span and ends it.
+func EndClientSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,34 @@
+package tracing
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestMiddlewareSkipsWebSocketRoute(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	defer otel.SetTracerProvider(prev)
+	otel.SetTracerProvider(tracesdk.NewTracerProvider(tracesdk.WithSpanProcessor(recorder)))
+
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(Middleware())
+	engine.GET("/ws", func(c *gin.Context) { c.Status(200) })
+	engine.GET("/api/v1/ping", func(c *gin.Context) { c.Status(200) })
+
+	engine.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/ws", nil))
+	if got := len(recorder.Ended()); got != 0 {
+		t.Fatalf("spans recorded for %s = %d, want 0 (should be skipped)", wsRoutePath, got)
+	}
+
+	engine.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/api/v1/ping", nil))
+	if got := len(recorder.Ended()); got != 1 {
+		t.Fatalf("spans recorded for /api/v1/ping = %d, want 1 (should be traced)", got)
+	}
+}
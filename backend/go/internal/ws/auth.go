@@ -0,0 +1,60 @@
+package ws
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// claims is the subset of the access token payload the hub cares about:
+// which tenant's channels a connection is allowed to subscribe to.
+type claims struct {
+	TenantID string `json:"tenant_id"`
+	jwt.RegisteredClaims
+}
+
+// validTenantID matches the tenant id formats this gateway issues
+// (UUIDs and short alphanumeric slugs). tenant_id is interpolated directly
+// into a Redis PSubscribe glob pattern (see connection.go), so it must
+// never contain '*', '?', '[', or other glob metacharacters -- this is
+// the one place that's enforced, since every connection's tenant comes
+// from here.
+var validTenantID = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+// authenticate validates the bearer token carried by the upgrade request
+// and returns the tenant it was issued for. Browsers can't set an
+// Authorization header on a WebSocket handshake, so the token is also
+// accepted as a "token" query parameter, same as gqlgen's default
+// websocket transport.
+func authenticate(r *http.Request, secret string) (string, error) {
+	token := r.URL.Query().Get("token")
+	if token == "" {
+		if auth := r.Header.Get("Authorization"); len(auth) > 7 && auth[:7] == "Bearer " {
+			token = auth[7:]
+		}
+	}
+	if token == "" {
+		return "", fmt.Errorf("missing token")
+	}
+
+	parsed, err := jwt.ParseWithClaims(token, &claims{}, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method %v", t.Header["alg"])
+		}
+		return []byte(secret), nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("parse token: %w", err)
+	}
+
+	c, ok := parsed.Claims.(*claims)
+	if !ok || !parsed.Valid || c.TenantID == "" {
+		return "", fmt.Errorf("token missing tenant_id claim")
+	}
+	if !validTenantID.MatchString(c.TenantID) {
+		return "", fmt.Errorf("token tenant_id has an invalid format")
+	}
+	return c.TenantID, nil
+}
@@ -0,0 +1,61 @@
+package ws
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const testSecret = "test-secret"
+
+func signToken(t *testing.T, tenantID string) string {
+	t.Helper()
+	tok := jwt.NewWithClaims(jwt.SigningMethodHS256, &claims{TenantID: tenantID})
+	signed, err := tok.SignedString([]byte(testSecret))
+	if err != nil {
+		t.Fatalf("sign token: %v", err)
+	}
+	return signed
+}
+
+func requestWithToken(token string) *http.Request {
+	r := &http.Request{Header: http.Header{}, URL: &url.URL{}}
+	q := url.Values{"token": {token}}
+	r.URL.RawQuery = q.Encode()
+	return r
+}
+
+func TestAuthenticate(t *testing.T) {
+	t.Run("a well-formed tenant id is accepted", func(t *testing.T) {
+		token := signToken(t, "tenant-123")
+		tenantID, err := authenticate(requestWithToken(token), testSecret)
+		if err != nil {
+			t.Fatalf("authenticate() error = %v, want nil", err)
+		}
+		if tenantID != "tenant-123" {
+			t.Fatalf("authenticate() = %q, want %q", tenantID, "tenant-123")
+		}
+	})
+
+	t.Run("a tenant id carrying a redis glob metacharacter is rejected", func(t *testing.T) {
+		token := signToken(t, "tenant-*")
+		if _, err := authenticate(requestWithToken(token), testSecret); err == nil {
+			t.Fatal("authenticate() error = nil, want an error for a glob metacharacter in tenant_id")
+		}
+	})
+
+	t.Run("an empty tenant id is rejected", func(t *testing.T) {
+		token := signToken(t, "")
+		if _, err := authenticate(requestWithToken(token), testSecret); err == nil {
+			t.Fatal("authenticate() error = nil, want an error for a missing tenant_id")
+		}
+	})
+
+	t.Run("a missing token is rejected", func(t *testing.T) {
+		if _, err := authenticate(requestWithToken(""), testSecret); err == nil {
+			t.Fatal("authenticate() error = nil, want an error for a missing token")
+		}
+	})
+}
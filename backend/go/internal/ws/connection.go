@@ -0,0 +1,219 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/sirupsen/logrus"
+
+	"github.com/olympuscloud/olympus-cloud-gcp/backend/go/internal/redisclient"
+)
+
+const (
+	// sendQueueSize bounds how many not-yet-written messages a slow client
+	// can accumulate before the hub starts dropping the oldest ones rather
+	// than let memory grow unbounded or block the Redis fan-out goroutine.
+	sendQueueSize = 256
+
+	writeWait  = 10 * time.Second
+	pongWait   = 60 * time.Second
+	pingPeriod = (pongWait * 9) / 10
+)
+
+// conn is one authenticated WebSocket connection, subscribed to every
+// Redis pub/sub channel published under its tenant.
+type conn struct {
+	ws       *websocket.Conn
+	redis    *redisclient.Service
+	tenantID string
+
+	// graphqlMode is true when the client negotiated the
+	// graphql-transport-ws subprotocol, in which case outgoing messages
+	// are wrapped as protocol frames instead of forwarded raw.
+	graphqlMode bool
+
+	// subIDMu guards subID, which is written by readPump's goroutine on
+	// subscribe/complete and read by subscribeTenant's goroutine on every
+	// fanned-out Redis message.
+	subIDMu sync.RWMutex
+	subID   string // the one active subscription id, in graphql mode
+
+	send chan []byte
+}
+
+func newConn(ws *websocket.Conn, redis *redisclient.Service, tenantID string) *conn {
+	return &conn{
+		ws:          ws,
+		redis:       redis,
+		tenantID:    tenantID,
+		graphqlMode: ws.Subprotocol() == graphqlTransportWSSubprotocol,
+		send:        make(chan []byte, sendQueueSize),
+	}
+}
+
+// serve subscribes the connection to its tenant's Redis channels and pumps
+// messages until the client disconnects. It blocks until the connection is
+// closed.
+func (c *conn) serve() {
+	connectionsActive.Inc()
+	defer connectionsActive.Dec()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go c.subscribeTenant(ctx)
+	go c.writePump()
+
+	c.readPump(cancel)
+}
+
+// subscribeTenant relays every message published to tenant:{id}:* into the
+// connection's bounded send queue, wrapping it as a graphql-transport-ws
+// "next" frame once a subscribe op has set c.subID.
+func (c *conn) subscribeTenant(ctx context.Context) {
+	pubsub := c.redis.PSubscribe(ctx, "tenant:"+c.tenantID+":*")
+	defer pubsub.Close()
+
+	for {
+		msg, err := pubsub.ReceiveMessage(ctx)
+		if err != nil {
+			return
+		}
+		c.enqueue(c.encode(msg.Channel, []byte(msg.Payload)))
+	}
+}
+
+func (c *conn) encode(channel string, payload []byte) []byte {
+	if !c.graphqlMode {
+		return mustMarshal(struct {
+			Channel string          `json:"channel"`
+			Payload json.RawMessage `json:"payload"`
+		}{Channel: channel, Payload: payload})
+	}
+	c.subIDMu.RLock()
+	subID := c.subID
+	c.subIDMu.RUnlock()
+	if subID == "" {
+		return nil
+	}
+	return mustMarshal(gqlMessage{ID: subID, Type: gqlNext, Payload: mustMarshal(struct {
+		Channel string          `json:"channel"`
+		Payload json.RawMessage `json:"payload"`
+	}{Channel: channel, Payload: payload})})
+}
+
+// enqueue drops the oldest queued message rather than block when the
+// client can't keep up, so one slow subscriber never stalls Redis delivery
+// to everyone else.
+func (c *conn) enqueue(data []byte) {
+	if data == nil {
+		return
+	}
+	select {
+	case c.send <- data:
+		messagesSentTotal.Inc()
+		return
+	default:
+	}
+
+	select {
+	case <-c.send:
+		messagesDroppedTotal.Inc()
+	default:
+	}
+	select {
+	case c.send <- data:
+		messagesSentTotal.Inc()
+	default:
+	}
+}
+
+func (c *conn) writePump() {
+	ticker := time.NewTicker(pingPeriod)
+	defer func() {
+		ticker.Stop()
+		c.ws.Close()
+	}()
+
+	for {
+		select {
+		case msg, ok := <-c.send:
+			c.ws.SetWriteDeadline(time.Now().Add(writeWait))
+			if !ok {
+				c.ws.WriteMessage(websocket.CloseMessage, []byte{})
+				return
+			}
+			if err := c.ws.WriteMessage(websocket.TextMessage, msg); err != nil {
+				return
+			}
+		case <-ticker.C:
+			c.ws.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.ws.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (c *conn) readPump(cancel context.CancelFunc) {
+	defer func() {
+		cancel()
+		c.ws.Close()
+	}()
+
+	c.ws.SetReadDeadline(time.Now().Add(pongWait))
+	c.ws.SetPongHandler(func(string) error {
+		c.ws.SetReadDeadline(time.Now().Add(pongWait))
+		return nil
+	})
+
+	for {
+		_, data, err := c.ws.ReadMessage()
+		if err != nil {
+			return
+		}
+		if c.graphqlMode {
+			c.handleGraphQLMessage(data)
+		}
+	}
+}
+
+// handleGraphQLMessage implements just enough of graphql-transport-ws to
+// let a gqlgen subscription client ride the hub: connection_init/ack and a
+// single active subscribe per connection, whose events are every message
+// on the connection's tenant channels. There is no GraphQL execution
+// engine behind it yet, so the subscription payload (query/variables) is
+// accepted but not evaluated -- it's bounded to "same tenant fan-out,
+// different envelope" until a real subscription resolver exists.
+func (c *conn) handleGraphQLMessage(data []byte) {
+	var msg gqlMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		logrus.WithError(err).Warn("ws: invalid graphql-transport-ws frame")
+		return
+	}
+
+	switch msg.Type {
+	case gqlConnectionInit:
+		c.enqueueRaw(mustMarshal(gqlMessage{Type: gqlConnectionAck}))
+	case gqlSubscribe:
+		c.subIDMu.Lock()
+		c.subID = msg.ID
+		c.subIDMu.Unlock()
+	case gqlComplete:
+		c.subIDMu.Lock()
+		c.subID = ""
+		c.subIDMu.Unlock()
+	}
+}
+
+func (c *conn) enqueueRaw(data []byte) {
+	select {
+	case c.send <- data:
+		messagesSentTotal.Inc()
+	default:
+		messagesDroppedTotal.Inc()
+	}
+}
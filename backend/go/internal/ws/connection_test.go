@@ -0,0 +1,31 @@
+package ws
+
+import "testing"
+
+func TestEnqueueDropsOldestWhenFull(t *testing.T) {
+	c := &conn{send: make(chan []byte, 2)}
+
+	c.enqueue([]byte("1"))
+	c.enqueue([]byte("2"))
+	c.enqueue([]byte("3")) // queue is full; "1" should be dropped for this
+
+	got := []string{string(<-c.send), string(<-c.send)}
+	want := []string{"2", "3"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("queued messages = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestEnqueueIgnoresNil(t *testing.T) {
+	c := &conn{send: make(chan []byte, 1)}
+
+	c.enqueue(nil)
+
+	select {
+	case msg := <-c.send:
+		t.Fatalf("enqueue(nil) queued %q, want nothing queued", msg)
+	default:
+	}
+}
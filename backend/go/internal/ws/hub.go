@@ -0,0 +1,84 @@
+// Package ws exposes a /ws endpoint that authenticates via JWT and fans
+// Redis pub/sub messages out to WebSocket clients, scoped per tenant. It
+// also accepts the graphql-transport-ws subprotocol so a gqlgen
+// subscription client can ride the same connection.
+package ws
+
+import (
+	"net/http"
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/samber/do"
+	"github.com/sirupsen/logrus"
+
+	"github.com/olympuscloud/olympus-cloud-gcp/backend/go/internal/config"
+	"github.com/olympuscloud/olympus-cloud-gcp/backend/go/internal/redisclient"
+)
+
+// Hub upgrades authenticated requests to WebSocket connections and hands
+// each one off to serve its own Redis subscription.
+type Hub struct {
+	redis    *redisclient.Service
+	cfg      atomic.Pointer[config.Config]
+	upgrader websocket.Upgrader
+}
+
+// NewService builds a Hub from the injected Redis client and subscribes it
+// to the config Store, fitting the do.Provider[*Hub] shape so it can be
+// registered with container.New.
+//
+// The JWT secret and allowed CORS origins are read from the Store's
+// current Config on every request rather than captured once, so rotating
+// either takes effect on the next hot-reload without restarting the
+// gateway.
+func NewService(i *do.Injector) (*Hub, error) {
+	store := do.MustInvoke[*config.Store](i)
+	redis := do.MustInvoke[*redisclient.Service](i)
+
+	h := &Hub{redis: redis}
+	h.cfg.Store(store.Current())
+	store.Subscribe(func(cfg *config.Config) { h.cfg.Store(cfg) })
+
+	h.upgrader = websocket.Upgrader{
+		Subprotocols:    []string{graphqlTransportWSSubprotocol},
+		CheckOrigin:     h.checkOrigin,
+		ReadBufferSize:  4096,
+		WriteBufferSize: 4096,
+	}
+	return h, nil
+}
+
+func (h *Hub) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	for _, allowed := range h.cfg.Load().CorsAllowedOrigins {
+		if allowed == "*" || allowed == origin {
+			return true
+		}
+	}
+	return false
+}
+
+// Handler returns a gin handler that authenticates the upgrade request via
+// JWT and, once upgraded, serves the connection until it closes.
+func (h *Hub) Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantID, err := authenticate(c.Request, h.cfg.Load().JWTSecret)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+
+		ws, err := h.upgrader.Upgrade(c.Writer, c.Request, nil)
+		if err != nil {
+			logrus.WithError(err).Warn("ws: upgrade failed")
+			return
+		}
+
+		newConn(ws, h.redis, tenantID).serve()
+	}
+}
@@ -0,0 +1,24 @@
+package ws
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var (
+	connectionsActive = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "ws_connections_active",
+		Help: "Number of currently open WebSocket subscription connections.",
+	})
+
+	messagesSentTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "ws_messages_sent_total",
+		Help: "Total messages forwarded from Redis pub/sub to WebSocket clients.",
+	})
+
+	messagesDroppedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "ws_messages_dropped_total",
+		Help: "Total messages dropped because a connection's send queue was full.",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(connectionsActive, messagesSentTotal, messagesDroppedTotal)
+}
@@ -0,0 +1,35 @@
+package ws
+
+import "encoding/json"
+
+// These mirror the graphql-transport-ws subprotocol message types
+// (https://github.com/enisdenjo/graphql-ws/blob/master/PROTOCOL.md) so a
+// gqlgen client can speak to the hub over the same connection used for
+// Redis-backed subscriptions.
+const (
+	gqlConnectionInit = "connection_init"
+	gqlConnectionAck  = "connection_ack"
+	gqlSubscribe      = "subscribe"
+	gqlNext           = "next"
+	gqlComplete       = "complete"
+	gqlError          = "error"
+
+	graphqlTransportWSSubprotocol = "graphql-transport-ws"
+)
+
+type gqlMessage struct {
+	ID      string          `json:"id,omitempty"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+func mustMarshal(v any) []byte {
+	b, err := json.Marshal(v)
+	if err != nil {
+		// Only ever marshals the types defined in this package; a failure
+		// here means a programming error, not a runtime condition to
+		// recover from.
+		panic(err)
+	}
+	return b
+}